@@ -14,6 +14,7 @@ import (
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/database"
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/handlers"
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/middleware"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/scheduler"
 )
 
 func main() {
@@ -79,7 +80,7 @@ func main() {
 		middleware.AdminOnly(handlers.AdminCallbackHandler))
 	b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "", bot.MatchTypePrefix, handlers.CallbackHandler)
 
-	b.RegisterHandler(bot.HandlerTypeMessageText, "/start", bot.MatchTypeExact, handlers.StartHandler)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "start", bot.MatchTypeCommandStartOnly, handlers.StartHandler)
 	b.RegisterHandler(bot.HandlerTypeMessageText, "/help", bot.MatchTypeExact, handlers.HelpHandler)
 	b.RegisterHandler(bot.HandlerTypeMessageText, "/menu", bot.MatchTypeExact, handlers.MenuHandler)
 	b.RegisterHandler(bot.HandlerTypeMessageText, "/privacy", bot.MatchTypeExact, handlers.PrivacyHandler)
@@ -87,6 +88,8 @@ func main() {
 		middleware.AdminOnly(handlers.AdminPanelHandler))
 	b.RegisterHandler(bot.HandlerTypeMessageText, "/exportDB", bot.MatchTypeExact, middleware.AdminOnly(handlers.ExportDBHandler))
 
+	go scheduler.New(b).Start(ctx)
+
 	log.Println("Bot started...")
 	b.Start(ctx)
 }
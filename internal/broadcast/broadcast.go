@@ -0,0 +1,100 @@
+package broadcast
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/repository"
+)
+
+var userRepo = repository.NewUserRepository()
+
+type Result struct {
+	Total   int
+	Sent    int
+	Blocked int
+	Failed  int
+}
+
+// Message is a broadcast text, optionally sent with a photo (e.g. the poster
+// of the event it announces) and buttons.
+type Message struct {
+	Text        string
+	PhotoFileID string
+	ReplyMarkup models.ReplyMarkup
+}
+
+// Send delivers the message to one chat and can be passed to ToActive. With a
+// photo the text becomes its caption, or follows it as a separate message
+// when it is too long for one. The buttons go with the text.
+func (m Message) Send(ctx context.Context, b *bot.Bot, chatID int64) error {
+	if m.PhotoFileID != "" {
+		params := &bot.SendPhotoParams{
+			ChatID: chatID,
+			Photo:  &models.InputFileString{Data: m.PhotoFileID},
+		}
+		if messages.FitsCaption(m.Text) {
+			params.Caption = m.Text
+			params.ParseMode = models.ParseModeHTML
+			params.ReplyMarkup = m.ReplyMarkup
+		}
+
+		if _, err := b.SendPhoto(ctx, params); err != nil {
+			return err
+		}
+		if params.Caption != "" {
+			return nil
+		}
+	}
+
+	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        m.Text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: m.ReplyMarkup,
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+	return err
+}
+
+// SendFunc delivers one message to a single subscriber chat.
+type SendFunc func(ctx context.Context, b *bot.Bot, chatID int64) error
+
+// ToActive calls send for every active subscriber, marking users who
+// blocked the bot and pausing between messages to respect Telegram limits.
+func ToActive(ctx context.Context, b *bot.Bot, send SendFunc) (*Result, error) {
+	users, err := userRepo.GetActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active users: %w", err)
+	}
+
+	result := &Result{Total: len(users)}
+
+	for _, user := range users {
+		err := send(ctx, b, user.UserID)
+
+		if err != nil {
+			if strings.Contains(err.Error(), "bot was blocked") {
+				userRepo.SetBlocked(ctx, user.UserID, true)
+				result.Blocked++
+			} else {
+				log.Printf("Error sending broadcast to user %d: %v", user.UserID, err)
+				result.Failed++
+			}
+		} else {
+			result.Sent++
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return result, nil
+}
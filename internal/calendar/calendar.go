@@ -0,0 +1,145 @@
+package calendar
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/repository"
+)
+
+// Entry is a single item of the calendar: either a published one-off event or
+// a resolved occurrence of a recurring event.
+type Entry struct {
+	Title           string
+	Start           time.Time
+	Description     string
+	Location        string
+	Category        string
+	RegistrationURL string
+	// AllDay is set for one-off events entered without a time.
+	AllDay bool
+	// End is the exclusive end of the entry, or zero if it is not known. For
+	// all-day entries it is midnight after the last day.
+	End time.Time
+
+	Event      *models.Event
+	Occurrence *models.Occurrence
+}
+
+func (e *Entry) IsRecurring() bool {
+	return e.Occurrence != nil
+}
+
+type Service struct {
+	eventRepo     repository.EventRepository
+	recurringRepo repository.RecurringEventRepository
+	exceptionRepo repository.RecurringExceptionRepository
+	location      *time.Location
+}
+
+func NewService() *Service {
+	return &Service{
+		eventRepo:     repository.NewEventRepository(),
+		recurringRepo: repository.NewRecurringEventRepository(),
+		exceptionRepo: repository.NewRecurringExceptionRepository(),
+		location:      clock.Location(),
+	}
+}
+
+func (s *Service) Location() *time.Location {
+	return s.location
+}
+
+// Between returns the calendar entries taking place within [from, to), merging
+// published one-off events with occurrences of active recurring events.
+// Cancelled occurrences are left out.
+func (s *Service) Between(ctx context.Context, from, to time.Time) ([]Entry, error) {
+	from = from.In(s.location)
+	to = to.In(s.location)
+
+	events, err := s.eventRepo.GetPublishedBetween(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+
+	for i := range events {
+		entries = append(entries, s.EventEntry(&events[i]))
+	}
+
+	recurring, err := s.recurringRepo.GetActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exceptions, err := s.exceptionRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range recurring {
+		occurrences, err := recurring[i].ResolvedOccurrences(from, to, s.location, exceptions)
+		if err != nil {
+			log.Printf("Skipping recurring event %d in calendar: %v", recurring[i].ID, err)
+			continue
+		}
+
+		for j := range occurrences {
+			if occurrences[j].IsCancelled || !occurrences[j].Start.Before(to) {
+				continue
+			}
+			entries = append(entries, occurrenceEntry(&occurrences[j]))
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Start.Before(entries[j].Start) })
+
+	return entries, nil
+}
+
+// EventEntry converts the event to a calendar entry in Warsaw time.
+func (s *Service) EventEntry(event *models.Event) Entry {
+	entry := Entry{
+		Title:       event.Title,
+		Start:       event.Date.In(s.location),
+		Description: event.Description,
+		AllDay:      event.AllDay,
+		Event:       event,
+	}
+
+	if event.EndDate != nil {
+		entry.End = event.EndDate.In(s.location)
+		if event.AllDay {
+			entry.End = entry.End.AddDate(0, 0, 1)
+		}
+	}
+
+	if event.Location != nil {
+		entry.Location = *event.Location
+	}
+	if event.Category != nil {
+		entry.Category = *event.Category
+	}
+	if event.RegistrationURL != nil {
+		entry.RegistrationURL = *event.RegistrationURL
+	}
+
+	return entry
+}
+
+func occurrenceEntry(occurrence *models.Occurrence) Entry {
+	return Entry{
+		Title:           occurrence.Event.Title,
+		Start:           occurrence.Start,
+		Description:     occurrence.Event.Description,
+		Location:        occurrence.Location,
+		Category:        occurrence.Event.Category,
+		RegistrationURL: occurrence.Event.RegistrationURL,
+		Occurrence:      occurrence,
+	}
+}
@@ -0,0 +1,36 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+)
+
+// Period is a half-open range of Warsaw days [From, To) shown as one
+// calendar view.
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// Day returns the day containing t.
+func Day(t time.Time) Period {
+	from := clock.StartOfDay(t)
+	return Period{From: from, To: from.AddDate(0, 0, 1)}
+}
+
+// Week returns the Monday-to-Sunday week containing t, shifted by offset
+// weeks.
+func Week(t time.Time, offset int) Period {
+	from := clock.StartOfDay(t)
+	daysSinceMonday := (int(from.Weekday()) + 6) % 7
+	from = from.AddDate(0, 0, -daysSinceMonday+7*offset)
+	return Period{From: from, To: from.AddDate(0, 0, 7)}
+}
+
+// Month returns the calendar month containing t.
+func Month(t time.Time) Period {
+	from := clock.StartOfDay(t)
+	from = from.AddDate(0, 0, 1-from.Day())
+	return Period{From: from, To: from.AddDate(0, 1, 0)}
+}
@@ -0,0 +1,33 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+// Periods are Warsaw days, whatever the location of the time passed in.
+func TestPeriods(t *testing.T) {
+	// Monday 26.10.2026 00:30 in Warsaw, the day after the switch to winter time.
+	now := time.Date(2026, time.October, 25, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		period   Period
+		from, to string
+	}{
+		{"day", Day(now), "2026-10-26 00:00 CET", "2026-10-27 00:00 CET"},
+		{"week", Week(now, 0), "2026-10-26 00:00 CET", "2026-11-02 00:00 CET"},
+		{"previous week", Week(now, -1), "2026-10-19 00:00 CEST", "2026-10-26 00:00 CET"},
+		{"month", Month(now), "2026-10-01 00:00 CEST", "2026-11-01 00:00 CET"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from := tt.period.From.Format("2006-01-02 15:04 MST")
+			to := tt.period.To.Format("2006-01-02 15:04 MST")
+			if from != tt.from || to != tt.to {
+				t.Errorf("period = [%s, %s), want [%s, %s)", from, to, tt.from, tt.to)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Package clock keeps the bot on Warsaw time. Admins enter event times and
+// users see them in Europe/Warsaw; the database stores them in UTC.
+package clock
+
+import (
+	"log"
+	"time"
+
+	// Embedded zone data, so the bot keeps Warsaw time on hosts without
+	// a system tz database.
+	_ "time/tzdata"
+)
+
+const timezone = "Europe/Warsaw"
+
+var location = loadLocation()
+
+func loadLocation() *time.Location {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		log.Printf("Failed to load %s timezone, using local time: %v", timezone, err)
+		return time.Local
+	}
+	return location
+}
+
+// Location returns the Europe/Warsaw time zone.
+func Location() *time.Location {
+	return location
+}
+
+// Now returns the current time in Warsaw.
+func Now() time.Time {
+	return time.Now().In(location)
+}
+
+// Today returns midnight of the current day in Warsaw.
+func Today() time.Time {
+	return StartOfDay(Now())
+}
+
+// In returns t in Warsaw time, for display.
+func In(t time.Time) time.Time {
+	return t.In(location)
+}
+
+// StartOfDay returns midnight of the Warsaw day t falls on.
+func StartOfDay(t time.Time) time.Time {
+	t = t.In(location)
+	return Date(t.Year(), t.Month(), t.Day(), 0, 0)
+}
+
+// Date returns the moment the Warsaw wall clock shows the given date and
+// time. A time skipped by the switch to summer time is moved forward by an
+// hour; a time repeated on the switch back is taken in winter time.
+func Date(year int, month time.Month, day, hour, min int) time.Time {
+	return resolve(time.Date(year, month, day, hour, min, 0, 0, time.UTC))
+}
+
+// Parse parses a date or time entered in Warsaw time, e.g. with layout
+// "02.01.2006 15:04". The layout must not contain a zone.
+func Parse(layout, value string) (time.Time, error) {
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return resolve(t), nil
+}
+
+// WallClock reads the date and time of t, whatever its zone, as Warsaw time.
+// It converts times that were stored as Warsaw wall-clock time labelled UTC.
+func WallClock(t time.Time) time.Time {
+	return resolve(time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC))
+}
+
+// resolve returns the moment the Warsaw wall clock shows the date and time
+// of wall, given in UTC. time.Date leaves the offset it picks for skipped
+// and repeated times unspecified, so they are resolved here: the offset in
+// effect after a switch is preferred, and a time it does not fit, i.e. a
+// skipped one, takes the offset from before the switch.
+func resolve(wall time.Time) time.Time {
+	_, before := wall.AddDate(0, 0, -1).In(location).Zone()
+	_, after := wall.AddDate(0, 0, 1).In(location).Zone()
+
+	if t := wall.Add(-time.Duration(after) * time.Second).In(location); offset(t) == after {
+		return t
+	}
+	return wall.Add(-time.Duration(before) * time.Second).In(location)
+}
+
+func offset(t time.Time) int {
+	_, offset := t.Zone()
+	return offset
+}
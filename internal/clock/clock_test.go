@@ -0,0 +1,176 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+// In 2026 Poland switches to summer time (CEST, UTC+2) on 29 March at
+// 02:00 and back to winter time (CET, UTC+1) on 25 October at 03:00.
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		utc   string
+	}{
+		{"winter", "15.01.2026 16:00", "2026-01-15 15:00"},
+		{"day before summer time", "28.03.2026 16:00", "2026-03-28 15:00"},
+		{"first day of summer time", "29.03.2026 16:00", "2026-03-29 14:00"},
+		{"before the switch to summer time", "29.03.2026 01:59", "2026-03-29 00:59"},
+		{"skipped hour", "29.03.2026 02:30", "2026-03-29 01:30"},
+		{"after the switch to summer time", "29.03.2026 03:00", "2026-03-29 01:00"},
+		{"summer", "15.07.2026 16:00", "2026-07-15 14:00"},
+		{"day before winter time", "24.10.2026 16:00", "2026-10-24 14:00"},
+		{"first day of winter time", "25.10.2026 16:00", "2026-10-25 15:00"},
+		{"before the switch to winter time", "25.10.2026 01:59", "2026-10-24 23:59"},
+		{"repeated hour", "25.10.2026 02:30", "2026-10-25 01:30"},
+		{"after the switch to winter time", "25.10.2026 03:00", "2026-10-25 02:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse("02.01.2006 15:04", tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+			if utc := got.UTC().Format("2006-01-02 15:04"); utc != tt.utc {
+				t.Errorf("Parse(%q) = %s UTC, want %s UTC", tt.input, utc, tt.utc)
+			}
+		})
+	}
+}
+
+// Events are stored in UTC, so a time entered by an admin must read the
+// same when shown again, on either side of a switch.
+func TestStoredTimeRoundTrip(t *testing.T) {
+	inputs := []string{
+		"28.03.2026 16:00",
+		"29.03.2026 16:00",
+		"29.03.2026 03:00",
+		"24.10.2026 16:00",
+		"25.10.2026 16:00",
+		"25.10.2026 00:00",
+		"31.12.2026 23:30",
+	}
+
+	for _, input := range inputs {
+		parsed, err := Parse("02.01.2006 15:04", input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", input, err)
+		}
+
+		stored := parsed.UTC()
+		if got := In(stored).Format("02.01.2006 15:04"); got != input {
+			t.Errorf("%s stored as %s is shown as %s", input, stored, got)
+		}
+	}
+}
+
+// An event repeating at 16:00 keeps its wall-clock time across the switch,
+// even though the UTC time moves by an hour.
+func TestDateKeepsWallClockAcrossSwitch(t *testing.T) {
+	tests := []struct {
+		day time.Time
+		utc int
+	}{
+		{Date(2026, time.March, 28, 16, 0), 15},
+		{Date(2026, time.March, 29, 16, 0), 14},
+		{Date(2026, time.October, 24, 16, 0), 14},
+		{Date(2026, time.October, 25, 16, 0), 15},
+	}
+
+	for _, tt := range tests {
+		if hour := tt.day.UTC().Hour(); hour != tt.utc {
+			t.Errorf("%s is %d:00 UTC, want %d:00 UTC", tt.day, hour, tt.utc)
+		}
+		if next := tt.day.AddDate(0, 0, 1); next.Hour() != 16 {
+			t.Errorf("day after %s starts at %s", tt.day, next.Format("15:04"))
+		}
+	}
+}
+
+// Wall-clock times around the switches resolve to one documented moment:
+// skipped times an hour later, repeated times in winter time.
+func TestDateAtSwitches(t *testing.T) {
+	tests := []struct {
+		name string
+		got  time.Time
+		want string
+	}{
+		{"before the skipped hour", Date(2027, time.March, 28, 1, 59), "2027-03-28 00:59 UTC, 01:59 CET"},
+		{"start of the skipped hour", Date(2027, time.March, 28, 2, 0), "2027-03-28 01:00 UTC, 03:00 CEST"},
+		{"skipped hour", Date(2027, time.March, 28, 2, 30), "2027-03-28 01:30 UTC, 03:30 CEST"},
+		{"after the skipped hour", Date(2027, time.March, 28, 3, 0), "2027-03-28 01:00 UTC, 03:00 CEST"},
+		{"before the repeated hour", Date(2027, time.October, 31, 1, 59), "2027-10-30 23:59 UTC, 01:59 CEST"},
+		{"start of the repeated hour", Date(2027, time.October, 31, 2, 0), "2027-10-31 01:00 UTC, 02:00 CET"},
+		{"repeated hour", Date(2027, time.October, 31, 2, 30), "2027-10-31 01:30 UTC, 02:30 CET"},
+		{"after the repeated hour", Date(2027, time.October, 31, 3, 0), "2027-10-31 02:00 UTC, 03:00 CET"},
+		{"wall clock in the repeated hour", WallClock(time.Date(2027, time.October, 31, 2, 30, 0, 0, time.UTC)), "2027-10-31 01:30 UTC, 02:30 CET"},
+		{"wall clock in the skipped hour", WallClock(time.Date(2027, time.March, 28, 2, 30, 0, 0, time.UTC)), "2027-03-28 01:30 UTC, 03:30 CEST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.got.UTC().Format("2006-01-02 15:04 MST") + ", " + tt.got.Format("15:04 MST")
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+			if tt.got.Location() != Location() {
+				t.Errorf("%s is not in Warsaw time", tt.got)
+			}
+		})
+	}
+}
+
+func TestStartOfDay(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{"late evening in UTC is the next Warsaw day", time.Date(2026, time.July, 14, 22, 30, 0, 0, time.UTC), "2026-07-14 22:00"},
+		{"winter midnight", time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC), "2026-01-14 23:00"},
+		{"day of the switch to summer time", time.Date(2026, time.March, 29, 12, 0, 0, 0, time.UTC), "2026-03-28 23:00"},
+		{"day after the switch to summer time", time.Date(2026, time.March, 30, 12, 0, 0, 0, time.UTC), "2026-03-29 22:00"},
+		{"day of the switch to winter time", time.Date(2026, time.October, 25, 12, 0, 0, 0, time.UTC), "2026-10-24 22:00"},
+		{"day after the switch to winter time", time.Date(2026, time.October, 26, 12, 0, 0, 0, time.UTC), "2026-10-25 23:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StartOfDay(tt.t)
+			if utc := got.UTC().Format("2006-01-02 15:04"); utc != tt.want {
+				t.Errorf("StartOfDay(%s) = %s UTC, want %s UTC", tt.t, utc, tt.want)
+			}
+			if got.Hour() != 0 || got.Minute() != 0 {
+				t.Errorf("StartOfDay(%s) = %s, want Warsaw midnight", tt.t, got)
+			}
+		})
+	}
+}
+
+// Before times were stored in UTC, they were stored as the Warsaw wall
+// clock labelled UTC.
+func TestWallClock(t *testing.T) {
+	tests := []struct {
+		stored string
+		utc    string
+	}{
+		{"2026-03-28 16:00", "2026-03-28 15:00"},
+		{"2026-03-29 16:00", "2026-03-29 14:00"},
+		{"2026-10-24 16:00", "2026-10-24 14:00"},
+		{"2026-10-25 16:00", "2026-10-25 15:00"},
+		{"2026-10-25 00:00", "2026-10-24 22:00"},
+	}
+
+	for _, tt := range tests {
+		stored, err := time.Parse("2006-01-02 15:04", tt.stored)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if utc := WallClock(stored).UTC().Format("2006-01-02 15:04"); utc != tt.utc {
+			t.Errorf("WallClock(%s) = %s UTC, want %s UTC", tt.stored, utc, tt.utc)
+		}
+	}
+}
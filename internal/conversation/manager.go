@@ -31,9 +31,10 @@ func (m *Manager) SetState(userID int64, state string) {
 		conv.State = state
 	} else {
 		m.conversations[userID] = &models.ConversationState{
-			UserID:    userID,
-			State:     state,
-			EventData: &models.Event{},
+			UserID:             userID,
+			State:              state,
+			EventData:          &models.Event{},
+			RecurringEventData: &models.RecurringEvent{},
 		}
 	}
 }
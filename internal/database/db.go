@@ -6,8 +6,10 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
 	_ "modernc.org/sqlite"
 )
 
@@ -72,6 +74,34 @@ func createTables() error {
 	CREATE INDEX IF NOT EXISTS idx_events_date ON events(date);
 	CREATE INDEX IF NOT EXISTS idx_events_is_published ON events(is_published);
 	
+	CREATE TABLE IF NOT EXISTS event_reminders (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		event_id INTEGER NOT NULL,
+		offset_minutes INTEGER NOT NULL,
+		UNIQUE(event_id, offset_minutes)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_event_reminders_event ON event_reminders(event_id);
+
+	CREATE TABLE IF NOT EXISTS event_registrations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		event_id INTEGER NOT NULL,
+		user_id INTEGER NOT NULL,
+		status TEXT NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(event_id, user_id)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_event_registrations_event ON event_registrations(event_id, status);
+
+	CREATE TABLE IF NOT EXISTS categories (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		emoji TEXT NOT NULL DEFAULT '',
+		sort_order INTEGER NOT NULL DEFAULT 0,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
 	CREATE TABLE IF NOT EXISTS users (
 		user_id INTEGER PRIMARY KEY,
 		username TEXT,
@@ -105,6 +135,21 @@ func createTables() error {
 	CREATE INDEX IF NOT EXISTS idx_recurring_events_day ON recurring_events(day_of_week);
 	CREATE INDEX IF NOT EXISTS idx_recurring_events_active ON recurring_events(is_active);
 	
+	CREATE TABLE IF NOT EXISTS recurring_event_exceptions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		recurring_event_id INTEGER NOT NULL,
+		occurrence_date TEXT NOT NULL,
+		is_cancelled BOOLEAN NOT NULL DEFAULT 0,
+		override_date TEXT NOT NULL DEFAULT '',
+		override_time TEXT NOT NULL DEFAULT '',
+		override_location TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		created_by INTEGER NOT NULL,
+		UNIQUE(recurring_event_id, occurrence_date)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_recurring_event_exceptions_event ON recurring_event_exceptions(recurring_event_id);
+
 	CREATE TABLE IF NOT EXISTS reminder_log (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		reminder_key TEXT UNIQUE NOT NULL,
@@ -113,6 +158,11 @@ func createTables() error {
 
 	CREATE INDEX IF NOT EXISTS idx_reminder_log_key ON reminder_log(reminder_key);
 
+	CREATE TABLE IF NOT EXISTS scheduler_state (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		last_tick_at DATETIME NOT NULL
+	);
+
 	CREATE TABLE IF NOT EXISTS schema_migrations (
 		version INTEGER PRIMARY KEY,
 		applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
@@ -136,34 +186,137 @@ func createTables() error {
 func runMigrations() error {
 	migrations := []struct {
 		version int
-		sql     string
+		apply   func(tx *sqlx.Tx) error
 	}{
-		// {1, "ALTER TABLE users ADD COLUMN phone TEXT;"},
+		{1, execSQL("ALTER TABLE recurring_events ADD COLUMN rrule TEXT NOT NULL DEFAULT '';")},
+		{2, execSQL("ALTER TABLE recurring_events ADD COLUMN start_date TEXT NOT NULL DEFAULT '';")},
+		{3, execSQL("ALTER TABLE reminder_log ADD COLUMN status TEXT NOT NULL DEFAULT 'sent';")},
+		{4, execSQL("ALTER TABLE recurring_events ADD COLUMN holiday_policy TEXT NOT NULL DEFAULT 'run';")},
+		{5, execSQL("ALTER TABLE events ADD COLUMN publish_at DATETIME;")},
+		{6, execSQL("ALTER TABLE events ADD COLUMN capacity INTEGER NOT NULL DEFAULT 0;")},
+		{7, execSQL("ALTER TABLE event_registrations ADD COLUMN checked_in_at DATETIME;")},
+		{8, execSQL("ALTER TABLE events ADD COLUMN image_file_id TEXT;")},
+		// Seed the managed categories with the free-text ones typed so far.
+		// Spelling variants are merged by admins afterwards.
+		{9, execSQL(`UPDATE events SET category = trim(category) WHERE category IS NOT NULL;
+			UPDATE events SET category = NULL WHERE category = '';
+			INSERT OR IGNORE INTO categories (name, sort_order)
+				SELECT category, ROW_NUMBER() OVER (ORDER BY MIN(id))
+				FROM events WHERE category IS NOT NULL GROUP BY category;`)},
+		{10, execSQL("ALTER TABLE events ADD COLUMN end_date DATETIME;")},
+		// Events entered without a time used to be stored at 00:00, which is
+		// what all-day meant before the flag existed. Dates are stored as
+		// "YYYY-MM-DD HH:MM:SS ...", so the time starts at the 12th character.
+		{11, execSQL(`ALTER TABLE events ADD COLUMN all_day BOOLEAN NOT NULL DEFAULT 0;
+			UPDATE events SET all_day = 1 WHERE substr(date, 12, 5) = '00:00';`)},
+		{12, execSQL(`ALTER TABLE events ADD COLUMN latitude REAL;
+			ALTER TABLE events ADD COLUMN longitude REAL;`)},
+		{13, execSQL("ALTER TABLE events ADD COLUMN announced_at DATETIME;")},
+		{14, execSQL(`ALTER TABLE events ADD COLUMN report_url TEXT;
+			ALTER TABLE events ADD COLUMN archived_at DATETIME;`)},
+		// Migrations that need Go code share the sequence, so they always
+		// run in version order with the others.
+		{15, convertEventTimesToUTC},
 		// Add new migrations here in the future
 	}
 
+	previous := 0
+
 	for _, m := range migrations {
-		var exists int
-		err := DB.Get(&exists, "SELECT 1 FROM schema_migrations WHERE version = ?", m.version)
-		if err == nil && exists == 1 {
+		if m.version <= previous {
+			return fmt.Errorf("migration %d is listed after migration %d", m.version, previous)
+		}
+		previous = m.version
+
+		applied, err := migrationApplied(m.version)
+		if err != nil {
+			return err
+		}
+		if applied {
 			continue
 		}
 
-		if err != nil && err != sql.ErrNoRows {
-			return fmt.Errorf("failed to check migration %d: %w", m.version, err)
+		if err := runMigration(m.version, m.apply); err != nil {
+			return err
 		}
 
-		_, err = DB.Exec(m.sql)
-		if err != nil {
-			return fmt.Errorf("migration %d failed: %w", m.version, err)
+		log.Printf("Migration %d applied successfully", m.version)
+	}
+
+	return nil
+}
+
+// execSQL returns a migration that runs the statements in query.
+func execSQL(query string) func(tx *sqlx.Tx) error {
+	return func(tx *sqlx.Tx) error {
+		_, err := tx.Exec(query)
+		return err
+	}
+}
+
+func migrationApplied(version int) (bool, error) {
+	var exists int
+	err := DB.Get(&exists, "SELECT 1 FROM schema_migrations WHERE version = ?", version)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check migration %d: %w", version, err)
+	}
+	return exists == 1, nil
+}
+
+// runMigration applies the migration and records it in one transaction.
+func runMigration(version int, apply func(tx *sqlx.Tx) error) error {
+	tx, err := DB.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %d: %w", version, err)
+	}
+	defer tx.Rollback()
+
+	if err := apply(tx); err != nil {
+		return fmt.Errorf("migration %d failed: %w", version, err)
+	}
+
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES (?)", version); err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %d: %w", version, err)
+	}
+
+	return nil
+}
+
+// convertEventTimesToUTC converts event times stored as Warsaw wall-clock
+// time labelled UTC, how they were stored before, to UTC.
+func convertEventTimesToUTC(tx *sqlx.Tx) error {
+	var events []struct {
+		ID        int        `db:"id"`
+		Date      time.Time  `db:"date"`
+		EndDate   *time.Time `db:"end_date"`
+		PublishAt *time.Time `db:"publish_at"`
+	}
+
+	if err := tx.Select(&events, "SELECT id, date, end_date, publish_at FROM events"); err != nil {
+		return fmt.Errorf("failed to read event times: %w", err)
+	}
+
+	convert := func(t *time.Time) *time.Time {
+		if t == nil {
+			return nil
 		}
+		utc := clock.WallClock(*t).UTC()
+		return &utc
+	}
 
-		_, err = DB.Exec("INSERT INTO schema_migrations (version) VALUES (?)", m.version)
+	for _, event := range events {
+		_, err := tx.Exec("UPDATE events SET date = ?, end_date = ?, publish_at = ? WHERE id = ?",
+			*convert(&event.Date), convert(event.EndDate), convert(event.PublishAt), event.ID)
 		if err != nil {
-			return fmt.Errorf("failed to record migration %d: %w", m.version, err)
+			return fmt.Errorf("failed to convert times of event %d: %w", event.ID, err)
 		}
-
-		log.Printf("Migration %d applied successfully", m.version)
 	}
 
 	return nil
@@ -3,15 +3,17 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"html"
 	"log"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/broadcast"
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/conversation"
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
 	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
 )
 
@@ -36,6 +38,56 @@ func AdminCallbackHandler(ctx context.Context, b *bot.Bot, update *models.Update
 
 	log.Printf("AdminCallbackHandler: received callback '%s' from user %d", data, callback.From.ID)
 
+	if strings.HasPrefix(data, "admin_recurring") {
+		RecurringCallbackHandler(ctx, b, callback)
+		return
+	}
+
+	if strings.HasPrefix(data, "admin_event_edit") {
+		EventEditCallbackHandler(ctx, b, callback)
+		return
+	}
+
+	if strings.HasPrefix(data, "admin_event_step") {
+		EventDialogCallbackHandler(ctx, b, callback)
+		return
+	}
+
+	if strings.HasPrefix(data, "admin_event_pub") {
+		EventPublishCallbackHandler(ctx, b, callback)
+		return
+	}
+
+	if strings.HasPrefix(data, "admin_event_clone") {
+		EventCloneCallbackHandler(ctx, b, callback)
+		return
+	}
+
+	if strings.HasPrefix(data, "admin_event_announce") {
+		EventAnnounceCallbackHandler(ctx, b, callback)
+		return
+	}
+
+	if strings.HasPrefix(data, "admin_event_import") {
+		EventImportCallbackHandler(ctx, b, callback)
+		return
+	}
+
+	if strings.HasPrefix(data, "admin_event_attendees") || strings.HasPrefix(data, "admin_event_checkin") {
+		EventAttendeesCallbackHandler(ctx, b, callback)
+		return
+	}
+
+	if strings.HasPrefix(data, "admin_event_reminder") {
+		EventReminderCallbackHandler(ctx, b, callback)
+		return
+	}
+
+	if strings.HasPrefix(data, "admin_categor") {
+		CategoryCallbackHandler(ctx, b, callback)
+		return
+	}
+
 	var text string
 	var keyboard *models.InlineKeyboardMarkup
 
@@ -99,6 +151,13 @@ func AdminCallbackHandler(ctx context.Context, b *bot.Bot, update *models.Update
 		})
 		return
 
+	case "admin_export_ics":
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+		sendCalendarICS(ctx, b, callback.Message.Message.Chat.ID)
+		return
+
 	case "admin_broadcast":
 		text = "📢 <b>Розсилка повідомлень</b>\n\n" +
 			"Оберіть тип розсилки:"
@@ -121,6 +180,11 @@ func AdminCallbackHandler(ctx context.Context, b *bot.Bot, update *models.Update
 		handleBroadcastCancel(ctx, b, callback)
 
 	default:
+		if month, ok := strings.CutPrefix(data, "admin_archive"); ok {
+			text, keyboard = getEventsArchive(ctx, strings.TrimPrefix(month, ":"))
+			break
+		}
+
 		log.Printf("Case: default - unknown command '%s'", data)
 		text = "Невідома команда"
 		keyboard = keyboards.AdminPanelKeyboard()
@@ -148,38 +212,57 @@ func AdminCallbackHandler(ctx context.Context, b *bot.Bot, update *models.Update
 }
 
 func getAdminEventsListText(ctx context.Context) string {
-	events, err := eventRepo.GetAll(ctx)
+	events, err := eventRepo.GetCurrent(ctx)
 	if err != nil {
 		log.Printf("Error getting events: %v", err)
 		return "❌ Помилка отримання подій з бази даних"
 	}
 
 	if len(events) == 0 {
-		return "📋 <b>Список подій</b>\n\nПодій поки що немає."
+		return "📋 <b>Список подій</b>\n\nЗапланованих подій немає. Минулі події — в архіві."
+	}
+
+	counts, err := eventRegistrationRepo.Counts(ctx)
+	if err != nil {
+		log.Printf("Error getting registration counts: %v", err)
 	}
 
 	text := "📋 <b>Список подій</b>\n\n"
 
 	for i, event := range events {
 		status := "✅"
-		if !event.IsPublished {
+		if event.IsScheduled() {
+			status = "⏰"
+		} else if !event.IsPublished {
 			status = "📝"
 		}
 
 		text += fmt.Sprintf(
 			"%s <b>%d. %s</b>\n"+
 				"📅 %s\n"+
+				"%s\n"+
 				"ID: %d\n\n",
 			status,
 			i+1,
-			event.Title,
-			formatEventDate(event.Date),
+			html.EscapeString(event.Title),
+			messages.FormatEventDate(&event),
+			formatAdminAttendees(&event, counts[event.ID]),
 			event.ID,
 		)
 	}
 
-	text += "\n💡 ✅ - опубліковано, 📝 - чернетка"
+	text += "\n💡 ✅ - опубліковано, 📝 - чернетка, ⏰ - заплановано"
+
+	return text
+}
 
+// formatAdminAttendees adds the number of arrivals to the attendee count
+// once check-in has started.
+func formatAdminAttendees(event *internalModels.Event, counts internalModels.RegistrationCounts) string {
+	text := formatAttendees(event, counts)
+	if counts.CheckedIn > 0 {
+		text += fmt.Sprintf(", прийшло: %d", counts.CheckedIn)
+	}
 	return text
 }
 
@@ -217,8 +300,8 @@ func DeleteEventHandler(ctx context.Context, b *bot.Bot, update *models.Update)
 			"<b>%s</b>\n"+
 			"📅 %s\n"+
 			"ID: %d",
-		event.Title,
-		formatEventDate(event.Date),
+		html.EscapeString(event.Title),
+		messages.FormatEventDate(event),
 		event.ID,
 	)
 
@@ -264,6 +347,14 @@ func handleDeleteConfirm(ctx context.Context, b *bot.Bot, callback *models.Callb
 		return
 	}
 
+	if err := eventReminderRepo.DeleteByEvent(ctx, eventID); err != nil {
+		log.Printf("Error deleting reminders of event %d: %v", eventID, err)
+	}
+
+	if err := eventRegistrationRepo.DeleteByEvent(ctx, eventID); err != nil {
+		log.Printf("Error deleting registrations of event %d: %v", eventID, err)
+	}
+
 	log.Printf("Event %d deleted successfully", eventID)
 	conv.ClearState(userID)
 
@@ -368,10 +459,10 @@ func getAdminUsersListText(ctx context.Context) string {
 				"    ID: %d | %s\n\n",
 			status,
 			i+1,
-			user.FirstName,
+			html.EscapeString(user.FirstName),
 			username,
 			user.UserID,
-			formatEventDate(user.SubscribedAt),
+			user.SubscribedAt.Format("02.01.2006 15:04"),
 		)
 	}
 
@@ -494,7 +585,14 @@ func handleBroadcastCancel(ctx context.Context, b *bot.Bot, callback *models.Cal
 }
 
 func sendBroadcast(ctx context.Context, b *bot.Bot, adminChatID int64, messageID int, text string) {
-	users, err := userRepo.GetActive(ctx)
+	result, err := broadcast.ToActive(ctx, b, func(ctx context.Context, b *bot.Bot, chatID int64) error {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      text,
+			ParseMode: models.ParseModeHTML,
+		})
+		return err
+	})
 	if err != nil {
 		log.Printf("Error getting active users for broadcast: %v", err)
 		b.EditMessageText(ctx, &bot.EditMessageTextParams{
@@ -506,44 +604,7 @@ func sendBroadcast(ctx context.Context, b *bot.Bot, adminChatID int64, messageID
 		return
 	}
 
-	successCount := 0
-	blockedCount := 0
-	errorCount := 0
-
-	for _, user := range users {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID:    user.UserID,
-			Text:      text,
-			ParseMode: models.ParseModeHTML,
-		})
-
-		if err != nil {
-			if strings.Contains(err.Error(), "bot was blocked") {
-				userRepo.SetBlocked(ctx, user.UserID, true)
-				blockedCount++
-			} else {
-				log.Printf("Error sending broadcast to user %d: %v", user.UserID, err)
-				errorCount++
-			}
-		} else {
-			successCount++
-		}
-
-		time.Sleep(50 * time.Millisecond)
-	}
-
-	resultText := fmt.Sprintf(
-		"✅ <b>Розсилка завершена!</b>\n\n"+
-			"📊 <b>Статистика:</b>\n"+
-			"✅ Надіслано: <b>%d</b>\n"+
-			"❌ Заблокували бота: <b>%d</b>\n"+
-			"⚠️ Помилки: <b>%d</b>\n"+
-			"📝 Всього: <b>%d</b>",
-		successCount,
-		blockedCount,
-		errorCount,
-		len(users),
-	)
+	resultText := "✅ <b>Розсилка завершена!</b>\n\n" + formatBroadcastResult(result)
 
 	keyboard := keyboards.AdminPanelKeyboard()
 
@@ -555,3 +616,17 @@ func sendBroadcast(ctx context.Context, b *bot.Bot, adminChatID int64, messageID
 		ReplyMarkup: keyboard,
 	})
 }
+
+func formatBroadcastResult(result *broadcast.Result) string {
+	return fmt.Sprintf(
+		"📊 <b>Статистика:</b>\n"+
+			"✅ Надіслано: <b>%d</b>\n"+
+			"❌ Заблокували бота: <b>%d</b>\n"+
+			"⚠️ Помилки: <b>%d</b>\n"+
+			"📝 Всього: <b>%d</b>",
+		result.Sent,
+		result.Blocked,
+		result.Failed,
+		result.Total,
+	)
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/calendar"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+// calendarMaxLength keeps calendar views below Telegram's 4096 character limit.
+const calendarMaxLength = 3800
+
+var calendarService = calendar.NewService()
+
+// getCalendarView renders the calendar view requested by a calendar_*
+// callback: "calendar_today", "calendar_week:<offset>" or "calendar_month".
+func getCalendarView(ctx context.Context, data string) (string, *models.InlineKeyboardMarkup) {
+	view, param, _ := strings.Cut(data, ":")
+	now := clock.Now()
+
+	switch view {
+	case "calendar_today":
+		period := calendar.Day(now)
+		title := fmt.Sprintf("📅 <b>Сьогодні, %s</b>", formatCalendarDay(period.From))
+		return getCalendarText(ctx, title, period, false), keyboards.CalendarKeyboard(view, 0)
+
+	case "calendar_month":
+		period := calendar.Month(now)
+		title := fmt.Sprintf("📅 <b>%s %d</b>", messages.MonthName(period.From.Month()), period.From.Year())
+		return getCalendarText(ctx, title, period, true), keyboards.CalendarKeyboard(view, 0)
+
+	default:
+		offset, _ := strconv.Atoi(param)
+		period := calendar.Week(now, offset)
+		title := fmt.Sprintf("📅 <b>Тиждень %s – %s</b>",
+			period.From.Format("02.01"), period.To.AddDate(0, 0, -1).Format("02.01"))
+		if offset == 0 {
+			title += " (цей тиждень)"
+		}
+		return getCalendarText(ctx, title, period, true), keyboards.CalendarKeyboard("calendar_week", offset)
+	}
+}
+
+func getCalendarText(ctx context.Context, title string, period calendar.Period, groupByDay bool) string {
+	entries, err := calendarService.Between(ctx, period.From, period.To)
+	if err != nil {
+		log.Printf("Error getting calendar entries: %v", err)
+		return title + "\n\n❌ Помилка отримання подій. Спробуйте пізніше."
+	}
+
+	text := title + "\n"
+
+	if len(entries) == 0 {
+		return text + "\nНа цей період подій не заплановано."
+	}
+
+	var currentDay string
+
+	for i := range entries {
+		var block string
+
+		// Multi-day events that started earlier are shown on the first day
+		// of the period.
+		start := entries[i].Start
+		if start.Before(period.From) {
+			start = period.From
+		}
+
+		day := start.Format(internalModels.StartDateLayout)
+		if groupByDay && day != currentDay {
+			currentDay = day
+			block += fmt.Sprintf("\n<b>%s</b>\n", formatCalendarDay(start))
+		} else if !groupByDay {
+			block += "\n"
+		}
+
+		block += formatCalendarEntry(&entries[i])
+
+		if len(text)+len(block) > calendarMaxLength {
+			text += fmt.Sprintf("\n… та ще %s", messages.Plural(len(entries)-i, "подія", "події", "подій"))
+			break
+		}
+		text += block
+	}
+
+	text += "\n💡 🔁 - регулярна подія"
+
+	return text
+}
+
+func formatCalendarDay(t time.Time) string {
+	return fmt.Sprintf("%s, %s", internalModels.WeekdayName(int(t.Weekday())), t.Format("02.01"))
+}
+
+func formatCalendarEntry(entry *calendar.Entry) string {
+	line := "🗓 Увесь день"
+	switch {
+	case entry.Event != nil && entry.Event.IsMultiDay():
+		line = "🗓 " + messages.FormatEventDate(entry.Event)
+	case !entry.AllDay:
+		line = "🕒 " + entry.Start.Format("15:04")
+		if !entry.End.IsZero() {
+			line += "–" + entry.End.Format("15:04")
+		}
+	}
+
+	line += fmt.Sprintf(" — <b>%s</b>", html.EscapeString(entry.Title))
+
+	if entry.IsRecurring() {
+		line += " 🔁"
+		if entry.Occurrence.IsChanged {
+			line += " ⚠️ <i>змінено</i>"
+		}
+	}
+
+	line += "\n"
+
+	if entry.Location != "" {
+		line += fmt.Sprintf("📍 %s\n", html.EscapeString(entry.Location))
+	}
+	if entry.RegistrationURL != "" {
+		line += fmt.Sprintf("🔗 <a href=\"%s\">Реєстрація</a>\n", html.EscapeString(entry.RegistrationURL))
+	}
+
+	return line
+}
@@ -0,0 +1,379 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/conversation"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+// Category names are shown on buttons, so they are kept short.
+const (
+	maxCategoryNameLength  = 40
+	maxCategoryEmojiLength = 8
+)
+
+func isCategoryState(state string) bool {
+	return state == internalModels.StateAwaitingCategoryName ||
+		state == internalModels.StateAwaitingCategoryEmoji
+}
+
+// CategoryCallbackHandler handles the admin_categor* callbacks of the event
+// categories settings.
+func CategoryCallbackHandler(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	action, param, _ := strings.Cut(callback.Data, ":")
+	categoryID, _ := strconv.Atoi(param)
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+
+	switch action {
+	case "admin_categories":
+		text, keyboard = getCategoriesText(ctx, "")
+
+	case "admin_category":
+		text, keyboard = getCategoryText(ctx, categoryID, "")
+
+	case "admin_category_add":
+		conv := conversation.GetManager()
+		conv.ClearState(callback.From.ID)
+		conv.SetState(callback.From.ID, internalModels.StateAwaitingCategoryName)
+		conv.GetConversation(callback.From.ID).CategoryData = &internalModels.Category{}
+
+		text = "🏷 <b>Нова категорія</b>\n\n" +
+			"Введіть <b>назву категорії</b>:\n\n" +
+			"Для скасування натисніть /cancel"
+
+	case "admin_category_rename", "admin_category_emoji":
+		category, err := categoryRepo.GetByID(ctx, categoryID)
+		if err != nil {
+			log.Printf("Error getting category %d: %v", categoryID, err)
+			text, keyboard = getCategoriesText(ctx, "❌ Категорію не знайдено.\n\n")
+			break
+		}
+
+		state := internalModels.StateAwaitingCategoryName
+		text = fmt.Sprintf("Поточна назва: <b>%s</b>\n\n", html.EscapeString(category.Name)) +
+			"Введіть нову назву. Якщо така категорія вже існує, категорії буде об'єднано.\n\n" +
+			"Для скасування натисніть /cancel"
+		if action == "admin_category_emoji" {
+			state = internalModels.StateAwaitingCategoryEmoji
+			text = fmt.Sprintf("Поточне емодзі: %s\n\n", formatCategoryEmoji(category.Emoji)) +
+				"Надішліть нове емодзі або /skip щоб прибрати його.\n\n" +
+				"Для скасування натисніть /cancel"
+		}
+
+		conv := conversation.GetManager()
+		conv.ClearState(callback.From.ID)
+		conv.SetState(callback.From.ID, state)
+		conv.GetConversation(callback.From.ID).CategoryData = category
+
+	case "admin_category_up", "admin_category_down":
+		delta := 1
+		if action == "admin_category_up" {
+			delta = -1
+		}
+		if err := categoryRepo.Move(ctx, categoryID, delta); err != nil {
+			log.Printf("Error moving category %d: %v", categoryID, err)
+		}
+		text, keyboard = getCategoryText(ctx, categoryID, "")
+
+	case "admin_category_delete":
+		category, err := categoryRepo.GetByID(ctx, categoryID)
+		if err != nil {
+			log.Printf("Error getting category %d: %v", categoryID, err)
+			text, keyboard = getCategoriesText(ctx, "❌ Категорію не знайдено.\n\n")
+			break
+		}
+
+		counts, err := categoryRepo.EventCounts(ctx)
+		if err != nil {
+			log.Printf("Error counting events by category: %v", err)
+		}
+
+		text = fmt.Sprintf("🗑️ Видалити категорію <b>%s</b>?\n\n", html.EscapeString(category.Label()))
+		if count := counts[category.Name]; count > 0 {
+			text += fmt.Sprintf("⚠️ %s залишаться без категорії.", messages.Plural(count, "подія", "події", "подій"))
+		}
+		keyboard = keyboards.CategoryDeleteConfirmKeyboard(category.ID)
+
+	case "admin_category_delete_confirm":
+		if err := categoryRepo.Delete(ctx, categoryID); err != nil {
+			log.Printf("Error deleting category %d: %v", categoryID, err)
+			text, keyboard = getCategoriesText(ctx, "❌ Помилка видалення категорії.\n\n")
+			break
+		}
+		log.Printf("Category %d deleted by %d", categoryID, callback.From.ID)
+		text, keyboard = getCategoriesText(ctx, "✅ Категорію видалено.\n\n")
+
+	default:
+		log.Printf("CategoryCallbackHandler: unknown command '%s'", callback.Data)
+		text = "Невідома команда"
+		keyboard = keyboards.AdminPanelKeyboard()
+	}
+
+	if callback.Message.Message == nil {
+		log.Printf("Error: callback message is nil")
+		return
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      callback.Message.Message.Chat.ID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+	})
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+}
+
+// HandleCategoryMessage takes the name or emoji of the category being added
+// or changed.
+func HandleCategoryMessage(ctx context.Context, b *bot.Bot, update *models.Update) {
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+	value := strings.TrimSpace(update.Message.Text)
+
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+	category := conversation.CategoryData
+
+	reply := func(text string) {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      text,
+			ParseMode: models.ParseModeHTML,
+		})
+	}
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+
+	switch conversation.State {
+	case internalModels.StateAwaitingCategoryName:
+		if value == "" || strings.HasPrefix(value, "/") {
+			reply("❌ Введіть назву категорії текстом:")
+			return
+		}
+		if utf8.RuneCountInString(value) > maxCategoryNameLength {
+			reply(fmt.Sprintf("❌ Назва задовга — не більше %d символів. Введіть коротшу:", maxCategoryNameLength))
+			return
+		}
+
+		existing, err := findOtherCategory(ctx, value, category.ID)
+		if err != nil {
+			log.Printf("Error finding category %q: %v", value, err)
+			reply("❌ Помилка перевірки назви. Спробуйте ще раз.")
+			return
+		}
+
+		if category.ID == 0 {
+			if existing != nil {
+				reply(fmt.Sprintf("❌ Категорія <b>%s</b> вже існує. Введіть іншу назву:", html.EscapeString(existing.Name)))
+				return
+			}
+			category.Name = value
+			conv.SetState(userID, internalModels.StateAwaitingCategoryEmoji)
+			reply("Надішліть <b>емодзі</b> для категорії, наприклад 🎸, або /skip щоб пропустити:")
+			return
+		}
+
+		conv.ClearState(userID)
+
+		if existing != nil {
+			if err := categoryRepo.Merge(ctx, category.ID, existing.ID); err != nil {
+				log.Printf("Error merging category %d into %d: %v", category.ID, existing.ID, err)
+				text, keyboard = getCategoryText(ctx, category.ID, "❌ Помилка об'єднання категорій.\n\n")
+				break
+			}
+			log.Printf("Category %d merged into %d by %d", category.ID, existing.ID, userID)
+			text, keyboard = getCategoryText(ctx, existing.ID, fmt.Sprintf("🔀 Категорію «%s» об'єднано з цією.\n\n", html.EscapeString(category.Name)))
+			break
+		}
+
+		if err := categoryRepo.Rename(ctx, category.ID, value); err != nil {
+			log.Printf("Error renaming category %d: %v", category.ID, err)
+			text, keyboard = getCategoryText(ctx, category.ID, "❌ Помилка збереження назви.\n\n")
+			break
+		}
+		text, keyboard = getCategoryText(ctx, category.ID, "✅ Назву змінено!\n\n")
+
+	case internalModels.StateAwaitingCategoryEmoji:
+		if value == "/skip" {
+			value = ""
+		}
+		if strings.HasPrefix(value, "/") || utf8.RuneCountInString(value) > maxCategoryEmojiLength {
+			reply("❌ Надішліть одне емодзі або /skip:")
+			return
+		}
+
+		conv.ClearState(userID)
+		category.Emoji = value
+
+		if category.ID == 0 {
+			if err := categoryRepo.Create(ctx, category); err != nil {
+				log.Printf("Error creating category: %v", err)
+				text, keyboard = getCategoriesText(ctx, "❌ Помилка збереження категорії.\n\n")
+				break
+			}
+			log.Printf("Category %d created by %d", category.ID, userID)
+			text, keyboard = getCategoriesText(ctx, fmt.Sprintf("✅ Категорію <b>%s</b> додано!\n\n", html.EscapeString(category.Label())))
+			break
+		}
+
+		if err := categoryRepo.SetEmoji(ctx, category.ID, value); err != nil {
+			log.Printf("Error updating category %d: %v", category.ID, err)
+			text, keyboard = getCategoryText(ctx, category.ID, "❌ Помилка збереження емодзі.\n\n")
+			break
+		}
+		text, keyboard = getCategoryText(ctx, category.ID, "✅ Емодзі змінено!\n\n")
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+	})
+}
+
+func getCategoriesText(ctx context.Context, notice string) (string, *models.InlineKeyboardMarkup) {
+	categories, err := categoryRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Error getting categories: %v", err)
+		return notice + "❌ Помилка отримання категорій.", keyboards.BackToAdminPanelKeyboard()
+	}
+
+	counts, err := categoryRepo.EventCounts(ctx)
+	if err != nil {
+		log.Printf("Error counting events by category: %v", err)
+	}
+
+	text := notice + "🏷 <b>Категорії подій</b>\n\n"
+
+	if len(categories) == 0 {
+		text += "Категорій ще немає.\n"
+	}
+	for i, category := range categories {
+		text += fmt.Sprintf("%d. %s — %s\n", i+1, html.EscapeString(category.Label()),
+			messages.Plural(counts[category.Name], "подія", "події", "подій"))
+	}
+
+	text += "\nКатегорію обирають кнопками під час додавання події, а користувачі можуть фільтрувати за нею список подій."
+
+	return text, keyboards.AdminCategoriesKeyboard(categories)
+}
+
+func getCategoryText(ctx context.Context, categoryID int, notice string) (string, *models.InlineKeyboardMarkup) {
+	category, err := categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		log.Printf("Error getting category %d: %v", categoryID, err)
+		return getCategoriesText(ctx, notice+"❌ Категорію не знайдено.\n\n")
+	}
+
+	counts, err := categoryRepo.EventCounts(ctx)
+	if err != nil {
+		log.Printf("Error counting events by category: %v", err)
+	}
+
+	text := notice +
+		fmt.Sprintf("🏷 <b>%s</b>\n\n", html.EscapeString(category.Name)) +
+		fmt.Sprintf("Емодзі: %s\n", formatCategoryEmoji(category.Emoji)) +
+		fmt.Sprintf("Подій: %d\n\n", counts[category.Name]) +
+		"Оберіть дію:"
+
+	return text, keyboards.AdminCategoryKeyboard(category.ID)
+}
+
+// findOtherCategory returns the category named name ignoring case, other than
+// the one with exceptID, or nil if there is none. Spelling variants left by
+// the free-text categories differ only in case, so renaming one of them to
+// the other's name must find the other one, not itself.
+func findOtherCategory(ctx context.Context, name string, exceptID int) (*internalModels.Category, error) {
+	categories, err := categoryRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range categories {
+		if categories[i].ID != exceptID && strings.EqualFold(categories[i].Name, name) {
+			return &categories[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func formatCategoryEmoji(emoji string) string {
+	if emoji == "" {
+		return "—"
+	}
+	return emoji
+}
+
+// eventCategoryKeyboard offers the managed categories for an event, with
+// callback data "<callbackPrefix>:<category id>".
+func eventCategoryKeyboard(ctx context.Context, callbackPrefix string) *models.InlineKeyboardMarkup {
+	categories, err := categoryRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Error getting categories: %v", err)
+	}
+	return keyboards.EventCategoryKeyboard(categories, callbackPrefix)
+}
+
+// resolveEventCategory maps a typed category to the managed one, ignoring
+// case. /skip clears the category; ok is false for unknown categories.
+func resolveEventCategory(ctx context.Context, value string) (category *string, ok bool) {
+	if value == "/skip" {
+		return nil, true
+	}
+
+	found, err := categoryRepo.FindByName(ctx, value)
+	if err != nil {
+		log.Printf("Error finding category %q: %v", value, err)
+	}
+	if found == nil {
+		return nil, false
+	}
+	return &found.Name, true
+}
+
+// categoryCallbackValue turns the category id of a picker button into the
+// value the dialogs accept as typed text: the category name or /skip.
+func categoryCallbackValue(ctx context.Context, param string) (string, bool) {
+	categoryID, err := strconv.Atoi(param)
+	if err != nil {
+		return "", false
+	}
+	if categoryID == 0 {
+		return "/skip", true
+	}
+
+	category, err := categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		log.Printf("Error getting category %d: %v", categoryID, err)
+		return "", false
+	}
+	return category.Name, true
+}
+
+func sendUnknownEventCategory(ctx context.Context, b *bot.Bot, chatID int64, callbackPrefix string) {
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        "❌ Такої категорії немає. Оберіть категорію кнопками нижче або /skip:",
+		ReplyMarkup: eventCategoryKeyboard(ctx, callbackPrefix),
+	})
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/broadcast"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+// EventAnnounceCallbackHandler handles "admin_event_announce:<id>", which asks
+// to confirm the announcement of a published event, and
+// "admin_event_announce_confirm:<id>", which sends it to all active
+// subscribers.
+func EventAnnounceCallbackHandler(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	action, param, _ := strings.Cut(callback.Data, ":")
+
+	if callback.Message.Message == nil {
+		log.Printf("Error: callback message is nil")
+		return
+	}
+
+	chatID := callback.Message.Message.Chat.ID
+	messageID := callback.Message.Message.ID
+
+	answer := func(text string) {
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            text,
+			ShowAlert:       text != "",
+		})
+	}
+
+	eventID, err := strconv.Atoi(param)
+	var event *internalModels.Event
+	if err == nil {
+		event, err = eventRepo.GetByID(ctx, eventID)
+	}
+	if err != nil {
+		log.Printf("Error getting event for announcement: %v", err)
+		answer("❌ Подію не знайдено")
+		return
+	}
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+
+	switch action {
+	case "admin_event_announce":
+		if keyboards.EventAnnounceButton(event) == nil {
+			answer("ℹ️ Подію вже анонсовано або вона ще не опублікована.")
+			return
+		}
+
+		activeCount := 0
+		if stats, err := userRepo.GetStats(ctx); err == nil {
+			activeCount = stats.Active
+		}
+
+		text = "📣 <b>Анонс події</b>\n\n" +
+			fmt.Sprintf("Підписники отримають картку події <b>%s</b> ", html.EscapeString(event.Title)) +
+			"з постером і кнопками реєстрації.\n\n" +
+			fmt.Sprintf("<b>Отримають:</b> %d активних користувачів\n\n", activeCount) +
+			"⚠️ Анонс надсилається лише один раз. Надіслати зараз?"
+		keyboard = keyboards.EventAnnounceConfirmKeyboard(event.ID)
+
+	case "admin_event_announce_confirm":
+		announced, err := eventRepo.MarkAnnounced(ctx, event.ID)
+		if err != nil {
+			log.Printf("Error marking event %d as announced: %v", event.ID, err)
+			answer("❌ Помилка збереження. Спробуйте пізніше.")
+			return
+		}
+		if !announced {
+			answer("ℹ️ Подію вже анонсовано або її знято з публікації.")
+			text, keyboard = getEventEditMenu(ctx, param)
+			break
+		}
+
+		log.Printf("📣 Event %d announced by %d", event.ID, callback.From.ID)
+
+		text = "⏳ <b>Розсилка анонсу розпочата...</b>\n\nБудь ласка, зачекайте."
+
+		go sendEventAnnouncement(ctx, b, chatID, messageID, event)
+
+	default:
+		log.Printf("EventAnnounceCallbackHandler: unknown command '%s'", callback.Data)
+		text = "Невідома команда"
+		keyboard = keyboards.AdminPanelKeyboard()
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+
+	answer("")
+}
+
+// sendEventAnnouncement sends the event card to all active subscribers and
+// reports the result in the admin's message. If the subscribers could not be
+// loaded, nothing was sent and the event may be announced again.
+func sendEventAnnouncement(ctx context.Context, b *bot.Bot, adminChatID int64, messageID int, event *internalModels.Event) {
+	message := broadcast.Message{
+		Text:        "📣 <b>Нова подія!</b>\n\n" + formatEventCard(event),
+		ReplyMarkup: keyboards.EventAnnouncementKeyboard(event),
+	}
+	if event.ImageFileID != nil {
+		message.PhotoFileID = *event.ImageFileID
+	}
+
+	backKeyboard := &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: "◀️ До події", CallbackData: fmt.Sprintf("admin_event_edit:%d", event.ID)}},
+		},
+	}
+
+	result, err := broadcast.ToActive(ctx, b, message.Send)
+	if err != nil {
+		log.Printf("Error getting active users for announcement of event %d: %v", event.ID, err)
+		if err := eventRepo.ClearAnnounced(ctx, event.ID); err != nil {
+			log.Printf("Error clearing announcement of event %d: %v", event.ID, err)
+		}
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      adminChatID,
+			MessageID:   messageID,
+			Text:        "❌ Помилка отримання списку користувачів. Спробуйте анонсувати подію пізніше.",
+			ReplyMarkup: backKeyboard,
+		})
+		return
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      adminChatID,
+		MessageID:   messageID,
+		Text:        "✅ <b>Анонс надіслано!</b>\n\n" + formatBroadcastResult(result),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: backKeyboard,
+	})
+}
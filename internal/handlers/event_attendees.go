@@ -0,0 +1,322 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+const checkInPageSize = 8
+
+// EventAttendeesCallbackHandler handles the admin_event_attendees* and
+// admin_event_checkin* callbacks: the registrant list of an event, its CSV
+// export and the check-in mode used at the door.
+func EventAttendeesCallbackHandler(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	action, param, _ := strings.Cut(callback.Data, ":")
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+
+	switch action {
+	case "admin_event_attendees":
+		text, keyboard = getEventAttendees(ctx, param)
+
+	case "admin_event_attendees_csv":
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            "📤 Готую список...",
+		})
+		sendAttendeesCSV(ctx, b, callback.From.ID, param)
+		return
+
+	case "admin_event_checkin":
+		idValue, page, _ := strings.Cut(param, ":")
+		number, _ := strconv.Atoi(page)
+		text, keyboard = getEventCheckIn(ctx, idValue, number)
+
+	case "admin_event_checkin_toggle":
+		// param: "<event id>:<registration id>:<page>"
+		parts := strings.Split(param, ":")
+		if len(parts) != 3 || !toggleCheckIn(ctx, parts[0], parts[1]) {
+			b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            "❌ Помилка відмітки",
+				ShowAlert:       true,
+			})
+			return
+		}
+		number, _ := strconv.Atoi(parts[2])
+		text, keyboard = getEventCheckIn(ctx, parts[0], number)
+
+	default:
+		log.Printf("EventAttendeesCallbackHandler: unknown command '%s'", callback.Data)
+		text = "Невідома команда"
+		keyboard = keyboards.AdminPanelKeyboard()
+	}
+
+	if callback.Message.Message == nil {
+		log.Printf("Error: callback message is nil")
+		return
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      callback.Message.Message.Chat.ID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+	})
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+}
+
+// loadEventAttendees fetches the event and its registrants for the admin
+// views.
+func loadEventAttendees(ctx context.Context, param string) (*internalModels.Event, []internalModels.Attendee, error) {
+	eventID, err := strconv.Atoi(param)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid event id %q: %w", param, err)
+	}
+
+	event, err := eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attendees, err := eventRegistrationRepo.GetAttendees(ctx, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return event, attendees, nil
+}
+
+func countAttendees(attendees []internalModels.Attendee) internalModels.RegistrationCounts {
+	var counts internalModels.RegistrationCounts
+	for _, attendee := range attendees {
+		if attendee.Status == internalModels.RegistrationWaitlist {
+			counts.Waitlist++
+		} else {
+			counts.Going++
+		}
+		if attendee.IsCheckedIn() {
+			counts.CheckedIn++
+		}
+	}
+	return counts
+}
+
+func getEventAttendees(ctx context.Context, param string) (string, *models.InlineKeyboardMarkup) {
+	event, attendees, err := loadEventAttendees(ctx, param)
+	if err != nil {
+		log.Printf("Error getting attendees of event %s: %v", param, err)
+		return "❌ Подію не знайдено.", keyboards.AdminEventsListKeyboard()
+	}
+
+	text := fmt.Sprintf("👥 <b>Учасники: %s</b>\n", html.EscapeString(event.Title)) +
+		fmt.Sprintf("📅 %s\n", messages.FormatEventDate(event)) +
+		formatAdminAttendees(event, countAttendees(attendees)) + "\n\n"
+
+	if len(attendees) == 0 {
+		text += "Поки що ніхто не зареєструвався."
+		return text, keyboards.EventAttendeesKeyboard(event.ID)
+	}
+
+	for i, attendee := range attendees {
+		line := fmt.Sprintf("%d. %s\n", i+1, formatAttendeeLine(&attendee))
+		if len(text)+len(line) > 3800 {
+			text += fmt.Sprintf("…і ще %d. Повний список — у CSV.\n", len(attendees)-i)
+			break
+		}
+		text += line
+	}
+
+	return text, keyboards.EventAttendeesKeyboard(event.ID)
+}
+
+func formatAttendeeLine(attendee *internalModels.Attendee) string {
+	text := html.EscapeString(attendee.DisplayName())
+	if attendee.Username != "" {
+		text += " @" + attendee.Username
+	}
+	if attendee.Status == internalModels.RegistrationWaitlist {
+		text += " ⏳"
+	}
+	if attendee.IsCheckedIn() {
+		text += " ✅"
+	}
+	return text
+}
+
+func getEventCheckIn(ctx context.Context, param string, page int) (string, *models.InlineKeyboardMarkup) {
+	event, attendees, err := loadEventAttendees(ctx, param)
+	if err != nil {
+		log.Printf("Error getting attendees of event %s: %v", param, err)
+		return "❌ Подію не знайдено.", keyboards.AdminEventsListKeyboard()
+	}
+
+	counts := countAttendees(attendees)
+
+	text := fmt.Sprintf("🚪 <b>Check-in: %s</b>\n", html.EscapeString(event.Title)) +
+		fmt.Sprintf("📅 %s\n\n", messages.FormatEventDate(event)) +
+		fmt.Sprintf("Прийшло: <b>%d</b> з %d\n\n", counts.CheckedIn, len(attendees))
+
+	if len(attendees) == 0 {
+		text += "Поки що ніхто не зареєструвався."
+		return text, keyboards.EventCheckInKeyboard(event.ID, nil, 0, 1)
+	}
+
+	text += "Натисніть на ім'я, щоб відмітити прихід (⏳ — з листа очікування)."
+
+	pages := (len(attendees) + checkInPageSize - 1) / checkInPageSize
+	page = max(0, min(page, pages-1))
+
+	first := page * checkInPageSize
+	last := min(first+checkInPageSize, len(attendees))
+
+	return text, keyboards.EventCheckInKeyboard(event.ID, attendees[first:last], page, pages)
+}
+
+// toggleCheckIn flips the arrival mark of a registration of the event.
+func toggleCheckIn(ctx context.Context, eventParam, registrationParam string) bool {
+	eventID, err := strconv.Atoi(eventParam)
+	if err != nil {
+		return false
+	}
+	registrationID, err := strconv.Atoi(registrationParam)
+	if err != nil {
+		return false
+	}
+
+	registrations, err := eventRegistrationRepo.GetByEvent(ctx, eventID)
+	if err != nil {
+		log.Printf("Error getting registrations of event %d: %v", eventID, err)
+		return false
+	}
+
+	for _, registration := range registrations {
+		if registration.ID != registrationID {
+			continue
+		}
+		if err := eventRegistrationRepo.SetCheckedIn(ctx, registration.ID, !registration.IsCheckedIn()); err != nil {
+			log.Printf("Error checking in registration %d: %v", registration.ID, err)
+			return false
+		}
+		return true
+	}
+
+	return false
+}
+
+func sendAttendeesCSV(ctx context.Context, b *bot.Bot, chatID int64, param string) {
+	event, attendees, err := loadEventAttendees(ctx, param)
+	if err != nil {
+		log.Printf("Error getting attendees of event %s: %v", param, err)
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   "❌ Помилка отримання списку учасників.",
+		})
+		return
+	}
+
+	data, err := attendeesCSV(attendees)
+	if err != nil {
+		log.Printf("Error building attendees CSV: %v", err)
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   fmt.Sprintf("❌ Помилка експорту: %v", err),
+		})
+		return
+	}
+
+	caption := fmt.Sprintf("👥 <b>%s</b>\n", html.EscapeString(event.Title)) +
+		fmt.Sprintf("📅 %s\n", messages.FormatEventDate(event)) +
+		formatAdminAttendees(event, countAttendees(attendees))
+
+	_, err = b.SendDocument(ctx, &bot.SendDocumentParams{
+		ChatID: chatID,
+		Document: &models.InputFileUpload{
+			Filename: fmt.Sprintf("event_%d_attendees.csv", event.ID),
+			Data:     bytes.NewReader(data),
+		},
+		Caption:     caption,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboards.EventAttendeesKeyboard(event.ID),
+	})
+	if err != nil {
+		log.Printf("Error sending attendees CSV: %v", err)
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   fmt.Sprintf("❌ Помилка відправки файлу: %v", err),
+		})
+		return
+	}
+
+	log.Printf("✅ Attendees of event %d exported to chat %d (%d rows)", event.ID, chatID, len(attendees))
+}
+
+// attendeesCSV renders the registrants as CSV. Times are in Warsaw time and
+// the UTF-8 BOM makes Excel read the Cyrillic names correctly.
+func attendeesCSV(attendees []internalModels.Attendee) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"Ім'я", "Username", "Час реєстрації", "Статус", "Прийшов"})
+
+	for _, attendee := range attendees {
+		username := ""
+		if attendee.Username != "" {
+			username = "@" + csvText(attendee.Username)
+		}
+
+		status := "зареєстрований"
+		if attendee.Status == internalModels.RegistrationWaitlist {
+			status = "лист очікування"
+		}
+
+		checkedIn := ""
+		if attendee.IsCheckedIn() {
+			checkedIn = clock.In(*attendee.CheckedInAt).Format("02.01.2006 15:04")
+		}
+
+		w.Write([]string{
+			csvText(attendee.DisplayName()),
+			username,
+			clock.In(attendee.CreatedAt).Format("02.01.2006 15:04"),
+			status,
+			checkedIn,
+		})
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// csvText guards a cell typed by a user against formula injection: Excel
+// runs cells starting with "=", "+", "-" or "@" as formulas, so such cells
+// get a leading "'".
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+func TestAttendeesCSV(t *testing.T) {
+	registered := time.Date(2026, time.October, 25, 0, 30, 0, 0, time.UTC)
+	arrived := time.Date(2026, time.October, 25, 15, 5, 0, 0, time.UTC)
+
+	attendee := func(name, username, status string, checkedIn *time.Time) internalModels.Attendee {
+		return internalModels.Attendee{
+			EventRegistration: internalModels.EventRegistration{
+				UserID:      42,
+				Status:      status,
+				CreatedAt:   registered,
+				CheckedInAt: checkedIn,
+			},
+			FirstName: name,
+			Username:  username,
+		}
+	}
+
+	data, err := attendeesCSV([]internalModels.Attendee{
+		attendee("Олена", "olena_k", internalModels.RegistrationGoing, &arrived),
+		attendee(`Петро "Пе, тя"`, "", internalModels.RegistrationWaitlist, nil),
+		attendee("=HYPERLINK(\"http://evil\")", "", internalModels.RegistrationGoing, nil),
+		attendee("+380 99", "", internalModels.RegistrationGoing, nil),
+		attendee("-Марія", "", internalModels.RegistrationGoing, nil),
+		attendee("", "", internalModels.RegistrationGoing, nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The UTF-8 BOM comes first. The bot adds the "@" of usernames after
+	// guarding them, so they stay as they are.
+	want := "\ufeff" +
+		"Ім'я,Username,Час реєстрації,Статус,Прийшов\n" +
+		"Олена,@olena_k,25.10.2026 02:30,зареєстрований,25.10.2026 16:05\n" +
+		"\"Петро \"\"Пе, тя\"\"\",,25.10.2026 02:30,лист очікування,\n" +
+		"\"'=HYPERLINK(\"\"http://evil\"\")\",,25.10.2026 02:30,зареєстрований,\n" +
+		"'+380 99,,25.10.2026 02:30,зареєстрований,\n" +
+		"'-Марія,,25.10.2026 02:30,зареєстрований,\n" +
+		"ID 42,,25.10.2026 02:30,зареєстрований,\n"
+
+	if got := string(data); got != want {
+		t.Errorf("attendeesCSV() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCSVText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{"Олена", "Олена"},
+		{"=1+1", "'=1+1"},
+		{"+48 600", "'+48 600"},
+		{"-2", "'-2"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"a=b", "a=b"},
+		{" =1", " =1"},
+	}
+
+	for _, tt := range tests {
+		if got := csvText(tt.value); got != tt.want {
+			t.Errorf("csvText(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
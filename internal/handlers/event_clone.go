@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/conversation"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+func isEventCloneState(state string) bool {
+	return state == internalModels.StateAwaitingCloneEventID ||
+		state == internalModels.StateAwaitingCloneDate
+}
+
+// EventCloneCallbackHandler handles "admin_event_clone", which asks for the
+// ID of the event to copy, and "admin_event_clone:<id>", which asks for the
+// date of the copy.
+func EventCloneCallbackHandler(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	_, param, _ := strings.Cut(callback.Data, ":")
+	userID := callback.From.ID
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+
+	if param == "" {
+		conv := conversation.GetManager()
+		conv.SetState(userID, internalModels.StateAwaitingCloneEventID)
+		text = "📑 <b>Дублювання події</b>\n\n" +
+			"Введіть <b>ID події</b>, яку потрібно скопіювати:\n\n" +
+			"Ви можете побачити ID в списку подій."
+		keyboard = keyboards.BackToAdminPanelKeyboard()
+	} else {
+		text, keyboard = startEventClone(ctx, userID, param)
+	}
+
+	if callback.Message.Message == nil {
+		log.Printf("Error: callback message is nil")
+		return
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      callback.Message.Message.Chat.ID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+}
+
+func HandleEventCloneMessage(ctx context.Context, b *bot.Bot, update *models.Update) {
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+	value := strings.TrimSpace(update.Message.Text)
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+
+	switch conversation.GetManager().GetState(userID) {
+	case internalModels.StateAwaitingCloneEventID:
+		if _, err := strconv.Atoi(value); err != nil {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   "❌ Неправильний формат ID. Введіть число.",
+			})
+			return
+		}
+		text, keyboard = startEventClone(ctx, userID, value)
+
+	case internalModels.StateAwaitingCloneDate:
+		period, err := parseEventPeriod(value)
+		if err != nil {
+			sendInvalidEventDate(ctx, b, chatID, err)
+			return
+		}
+		text, keyboard = createEventClone(ctx, userID, period)
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+}
+
+// startEventClone copies the event into the conversation and asks for the
+// date of the copy.
+func startEventClone(ctx context.Context, userID int64, param string) (string, *models.InlineKeyboardMarkup) {
+	conv := conversation.GetManager()
+	conv.ClearState(userID)
+
+	eventID, err := strconv.Atoi(param)
+	if err != nil {
+		return "❌ Подію не знайдено.", keyboards.AdminEventsListKeyboard()
+	}
+
+	event, err := eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		log.Printf("Error getting event %d to clone: %v", eventID, err)
+		return "❌ Подію з таким ID не знайдено.", keyboards.AdminEventsListKeyboard()
+	}
+
+	conv.SetState(userID, internalModels.StateAwaitingCloneDate)
+	conv.GetConversation(userID).EventData = eventCopy(event)
+
+	text := fmt.Sprintf("📑 <b>Дублювання: %s</b>\n\n", html.EscapeString(event.Title)) +
+		"Копія буде чернеткою з тими самими описом, місцем, категорією, " +
+		"реєстрацією, постером і кількістю місць.\n\n" +
+		fmt.Sprintf("Дата оригіналу: <b>%s</b>\n\n", messages.FormatEventDate(event)) +
+		"Введіть <b>дату нової події</b> в одному з форматів:\n" +
+		eventDateFormats + "\n\n" +
+		"Для скасування натисніть /cancel"
+
+	return text, nil
+}
+
+// createEventClone saves the copy with its new date and opens it in the
+// edit menu, so the admin only changes what differs.
+func createEventClone(ctx context.Context, userID int64, period eventPeriod) (string, *models.InlineKeyboardMarkup) {
+	conv := conversation.GetManager()
+	event := conv.GetConversation(userID).EventData
+	conv.ClearState(userID)
+
+	period.applyTo(event)
+	event.CreatedAt = time.Now()
+	event.CreatedBy = userID
+
+	if err := eventRepo.Create(ctx, event); err != nil {
+		log.Printf("Error creating event copy: %v", err)
+		return "❌ Помилка збереження події в базу даних.", keyboards.AdminEventsListKeyboard()
+	}
+
+	log.Printf("Event %d created as a copy by %d", event.ID, userID)
+
+	text, keyboard := getEventEditMenu(ctx, strconv.Itoa(event.ID))
+
+	return "✅ Копію збережено як чернетку. Змініть те, що відрізняється, і опублікуйте її.\n\n" + text, keyboard
+}
+
+// eventCopy returns a draft with the content of event. Its publication,
+// announcement, photo report and archiving are not copied.
+func eventCopy(event *internalModels.Event) *internalModels.Event {
+	copied := *event
+	copied.ID = 0
+	copied.IsPublished = false
+	copied.PublishAt = nil
+	copied.AnnouncedAt = nil
+	copied.ReportURL = nil
+	copied.ArchivedAt = nil
+	return &copied
+}
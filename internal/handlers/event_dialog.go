@@ -3,30 +3,288 @@ package handlers
 import (
 	"context"
 	"fmt"
-	"log"
+	"html"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/conversation"
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
 	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
 )
 
+func isEventDialogState(state string) bool {
+	switch state {
+	case internalModels.StateAwaitingTitle,
+		internalModels.StateAwaitingDate,
+		internalModels.StateAwaitingDesc,
+		internalModels.StateAwaitingLocation,
+		internalModels.StateAwaitingCategory,
+		internalModels.StateAwaitingRegURL,
+		internalModels.StateAwaitingImage,
+		internalModels.StateAwaitingConfirm:
+		return true
+	}
+	return false
+}
+
+// eventDialogStep is one step of the add-event dialog. field names the event
+// field the step fills, as used by formatEventField.
+type eventDialogStep struct {
+	state    string
+	field    string
+	prompt   string
+	optional bool
+}
+
+var eventDialogSteps = []eventDialogStep{
+	{
+		state:  internalModels.StateAwaitingTitle,
+		field:  "title",
+		prompt: "Введіть <b>назву події</b>:",
+	},
+	{
+		state: internalModels.StateAwaitingDate,
+		field: "date",
+		prompt: "Введіть <b>дату та час події</b>:\n\n" +
+			"Формат: <code>ДД.ММ.РРРР ГГ:ХХ</code> або <code>ДД.ММ.РРРР</code>, " +
+			"через дефіс можна додати завершення.\n" +
+			"Приклади:\n" +
+			eventDateFormats,
+	},
+	{
+		state:  internalModels.StateAwaitingDesc,
+		field:  "description",
+		prompt: "Введіть <b>опис події</b>:",
+	},
+	{
+		state: internalModels.StateAwaitingLocation,
+		field: "location",
+		prompt: "Введіть <b>місце проведення</b> (адресу) або надішліть " +
+			"геопозицію чи місце через 📎, щоб учасники бачили його на мапі:",
+		optional: true,
+	},
+	{
+		state: internalModels.StateAwaitingCategory,
+		field: "category",
+		prompt: "Оберіть <b>категорію події</b> кнопкою нижче або введіть її назву:\n\n" +
+			"Нові категорії додаються в адмін-панелі (🏷 Категорії).",
+		optional: true,
+	},
+	{
+		state: internalModels.StateAwaitingRegURL,
+		field: "registration_url",
+		prompt: "Введіть <b>посилання для реєстрації</b>:\n\n" +
+			"Наприклад: https://forms.google.com/...",
+		optional: true,
+	},
+	{
+		state:    internalModels.StateAwaitingImage,
+		field:    "image",
+		prompt:   "Надішліть <b>постер події</b> — фото (не файлом):",
+		optional: true,
+	},
+}
+
+// eventDialogStepIndex returns the position of state in the dialog. The
+// confirmation step comes right after the last input step.
+func eventDialogStepIndex(state string) int {
+	if state == internalModels.StateAwaitingConfirm {
+		return len(eventDialogSteps)
+	}
+	for i, step := range eventDialogSteps {
+		if step.state == state {
+			return i
+		}
+	}
+	return -1
+}
+
 func StartAddEventDialog(ctx context.Context, b *bot.Bot, userID int64, chatID int64) {
+	conversation.GetManager().ClearState(userID)
+
+	sendEventDialogStep(ctx, b, userID, chatID, 0, "➕ <b>Додавання нової події</b>\n\n")
+}
+
+// sendEventDialogStep moves the dialog to the given step and asks for its
+// value, showing the value entered earlier when the admin came back to it.
+func sendEventDialogStep(ctx context.Context, b *bot.Bot, userID int64, chatID int64, index int, notice string) {
+	step := eventDialogSteps[index]
+
 	conv := conversation.GetManager()
-	conv.SetState(userID, internalModels.StateAwaitingTitle)
+	conv.SetState(userID, step.state)
+	event := conv.GetConversation(userID).EventData
 
-	text := "➕ <b>Додавання нової події</b>\n\n" +
-		"Крок 1 з 6\n" +
-		"Введіть <b>назву події</b>:\n\n" +
-		"Для скасування натисніть /cancel"
-	b.SendMessage(ctx, &bot.SendMessageParams{
+	text := notice +
+		fmt.Sprintf("Крок %d з %d\n", index+1, len(eventDialogSteps)) +
+		step.prompt + "\n"
+
+	if eventFieldIsSet(event, step.field) {
+		text += fmt.Sprintf("\nЗараз: <b>%s</b>\n", formatEventField(event, step.field))
+	}
+
+	text += "\n"
+	if step.optional {
+		text += "Або натисніть /skip щоб пропустити\n"
+	}
+
+	params := &bot.SendMessageParams{
 		ChatID:    chatID,
-		Text:      text,
 		ParseMode: models.ParseModeHTML,
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	}
+
+	var keyboard *models.InlineKeyboardMarkup
+	if step.field == "category" {
+		keyboard = eventCategoryKeyboard(ctx, "admin_event_step_category")
+	}
+
+	if index > 0 {
+		text += "/back — повернутися на крок назад\n"
+		if keyboard == nil {
+			keyboard = &models.InlineKeyboardMarkup{}
+		}
+		keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, keyboards.EventDialogBackKeyboard().InlineKeyboard...)
+	}
+	if keyboard != nil {
+		params.ReplyMarkup = keyboard
+	}
+
+	params.Text = text + "Для скасування натисніть /cancel"
+
+	b.SendMessage(ctx, params)
+}
+
+func eventFieldIsSet(event *internalModels.Event, field string) bool {
+	if field == "date" {
+		return !event.Date.IsZero()
+	}
+	value := formatEventField(event, field)
+	return value != "" && value != "—"
+}
+
+// advanceEventDialog continues after a value was saved: to the next step, or
+// back to the preview when the admin is fixing a single field from it.
+func advanceEventDialog(ctx context.Context, b *bot.Bot, userID int64, chatID int64, notice string) {
+	conversation := conversation.GetManager().GetConversation(userID)
+	index := eventDialogStepIndex(conversation.State)
+
+	if conversation.ReturnToConfirm || index == len(eventDialogSteps)-1 {
+		showEventPreview(ctx, b, userID, chatID, notice)
+		return
+	}
+
+	sendEventDialogStep(ctx, b, userID, chatID, index+1, notice)
+}
+
+func stepBackEventDialog(ctx context.Context, b *bot.Bot, userID int64, chatID int64) {
+	conversation := conversation.GetManager().GetConversation(userID)
+	index := eventDialogStepIndex(conversation.State)
+
+	switch {
+	case conversation.ReturnToConfirm:
+		showEventPreview(ctx, b, userID, chatID, "")
+	case index <= 0:
+		sendEventDialogStep(ctx, b, userID, chatID, 0, "ℹ️ Це перший крок.\n\n")
+	default:
+		sendEventDialogStep(ctx, b, userID, chatID, index-1, "")
+	}
+}
+
+// showEventPreview renders the event exactly as users will see it in the
+// events list and on its card, with buttons to fix single fields before saving.
+func showEventPreview(ctx context.Context, b *bot.Bot, userID int64, chatID int64, notice string) {
+	conv := conversation.GetManager()
+	conv.SetState(userID, internalModels.StateAwaitingConfirm)
+
+	conversation := conv.GetConversation(userID)
+	conversation.ReturnToConfirm = false
+
+	if poster := conversation.EventData.ImageFileID; poster != nil {
+		b.SendPhoto(ctx, &bot.SendPhotoParams{
+			ChatID:  chatID,
+			Photo:   &models.InputFileString{Data: *poster},
+			Caption: "🖼 Постер події",
+		})
+	}
+
+	text := notice +
+		"👀 <b>Попередній перегляд</b>\n" +
+		"<i>Так подія виглядатиме у списку подій:</i>\n\n" +
+		formatEventListEntry(1, conversation.EventData) +
+		"\n<i>…і на її картці:</i>\n\n" +
+		formatEventCard(conversation.EventData) +
+		"\nОпублікувати подію зараз чи зберегти як чернетку?"
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboards.EventConfirmKeyboard(),
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+}
+
+// EventDialogCallbackHandler handles the "◀️ Назад" button of the add-event
+// dialog, its category buttons ("admin_event_step_category:<id>") and the
+// per-field edit buttons of its preview ("admin_event_step:<field>").
+func EventDialogCallbackHandler(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	userID := callback.From.ID
+	chatID := callback.Message.Message.Chat.ID
+	action, field, _ := strings.Cut(callback.Data, ":")
+
+	conv := conversation.GetManager()
+
+	state := conv.GetState(userID)
+	if !isEventDialogState(state) ||
+		action == "admin_event_step_category" && state != internalModels.StateAwaitingCategory {
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            "❌ Цей крок вже неактуальний",
+		})
+		return
+	}
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:    chatID,
+		MessageID: callback.Message.Message.ID,
 	})
+
+	if action == "admin_event_step_back" {
+		stepBackEventDialog(ctx, b, userID, chatID)
+		return
+	}
+
+	if action == "admin_event_step_category" {
+		value, ok := categoryCallbackValue(ctx, field)
+		if !ok {
+			sendUnknownEventCategory(ctx, b, chatID, "admin_event_step_category")
+			return
+		}
+		handleCategory(ctx, b, userID, chatID, value)
+		return
+	}
+
+	for i, step := range eventDialogSteps {
+		if step.field == field {
+			conv.GetConversation(userID).ReturnToConfirm = true
+			sendEventDialogStep(ctx, b, userID, chatID, i, "")
+			return
+		}
+	}
 }
 
 func HandleEventDialogMessage(ctx context.Context, b *bot.Bot, update *models.Update) {
@@ -46,6 +304,11 @@ func HandleEventDialogMessage(ctx context.Context, b *bot.Bot, update *models.Up
 		return
 	}
 
+	if text == "/back" {
+		stepBackEventDialog(ctx, b, userID, chatID)
+		return
+	}
+
 	switch state {
 	case internalModels.StateAwaitingTitle:
 		handleTitle(ctx, b, userID, chatID, text)
@@ -54,205 +317,335 @@ func HandleEventDialogMessage(ctx context.Context, b *bot.Bot, update *models.Up
 	case internalModels.StateAwaitingDesc:
 		handleDescription(ctx, b, userID, chatID, text)
 	case internalModels.StateAwaitingLocation:
-		handleLocation(ctx, b, userID, chatID, text)
+		handleLocation(ctx, b, userID, chatID, update.Message)
 	case internalModels.StateAwaitingCategory:
 		handleCategory(ctx, b, userID, chatID, text)
 	case internalModels.StateAwaitingRegURL:
 		handleRegistrationURL(ctx, b, userID, chatID, text)
+	case internalModels.StateAwaitingImage:
+		handleImage(ctx, b, userID, chatID, update.Message)
+	case internalModels.StateAwaitingConfirm:
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        "👆 Оберіть дію кнопками нижче:",
+			ReplyMarkup: keyboards.EventConfirmKeyboard(),
+		})
 	}
 }
 
 func handleTitle(ctx context.Context, b *bot.Bot, userID int64, chatID int64, title string) {
-	conv := conversation.GetManager()
-	conversation := conv.GetConversation(userID)
+	conversation := conversation.GetManager().GetConversation(userID)
 	conversation.EventData.Title = title
 
-	conv.SetState(userID, internalModels.StateAwaitingDate)
-
-	text := "✅ Назва збережена!\n\n" +
-		"Крок 2 з 6\n" +
-		"Введіть <b>дату та час події</b>:\n\n" +
-		"Формат: <code>ДД.ММ.РРРР ГГ:ХХ</code> або <code>ДД.ММ.РРРР</code>\n" +
-		"Приклади:\n" +
-		"• <code>25.12.2025 16:00</code>\n" +
-		"• <code>31.12.2025</code> (без часу)\n\n" +
-		"Для скасування натисніть /cancel"
-
-	b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID:    chatID,
-		Text:      text,
-		ParseMode: models.ParseModeHTML,
-	})
+	advanceEventDialog(ctx, b, userID, chatID, "✅ Назва збережена!\n\n")
 }
 
 func handleDate(ctx context.Context, b *bot.Bot, userID int64, chatID int64, dateStr string) {
 	dateStr = strings.TrimSpace(dateStr)
 
-	eventDate, err := parseEventDate(dateStr)
+	period, err := parseEventPeriod(dateStr)
 	if err != nil {
-		b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text: "❌ Неправильний формат дати!\n\n" +
-				"Використовуйте формат:\n" +
-				"• <code>25.12.2025 16:00</code>\n" +
-				"• <code>25.12.2025</code>\n\n" +
-				"Спробуйте ще раз:",
-			ParseMode: models.ParseModeHTML,
-		})
+		sendInvalidEventDate(ctx, b, chatID, err)
 		return
 	}
 
-	conv := conversation.GetManager()
-	conversation := conv.GetConversation(userID)
-	conversation.EventData.Date = eventDate
-
-	conv.SetState(userID, internalModels.StateAwaitingDesc)
-
-	text := "✅ Дата збережена!\n\n" +
-		"Крок 3 з 6\n" +
-		"Введіть <b>опис події</b>:\n\n" +
-		"Для скасування натисніть /cancel"
+	conversation := conversation.GetManager().GetConversation(userID)
+	period.applyTo(conversation.EventData)
 
-	b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID:    chatID,
-		Text:      text,
-		ParseMode: models.ParseModeHTML,
-	})
+	advanceEventDialog(ctx, b, userID, chatID, "✅ Дата збережена!\n\n")
 }
 
 func handleDescription(ctx context.Context, b *bot.Bot, userID int64, chatID int64, description string) {
-	conv := conversation.GetManager()
-	conversation := conv.GetConversation(userID)
+	conversation := conversation.GetManager().GetConversation(userID)
 	conversation.EventData.Description = description
 
-	conv.SetState(userID, internalModels.StateAwaitingLocation)
+	advanceEventDialog(ctx, b, userID, chatID, "✅ Опис збережено!\n\n")
+}
+
+// optionalDialogValue turns /skip into an empty optional field, so skipping a
+// step after /back also clears the value entered before.
+func optionalDialogValue(value string) *string {
+	if value == "/skip" {
+		return nil
+	}
+	return &value
+}
 
-	text := "✅ Опис збережено!\n\n" +
-		"Крок 4 з 6\n" +
-		"Введіть <b>місце проведення</b> (адресу):\n\n" +
-		"Або натисніть /skip щоб пропустити\n" +
-		"Для скасування натисніть /cancel"
+func handleLocation(ctx context.Context, b *bot.Bot, userID int64, chatID int64, message *models.Message) {
+	conversation := conversation.GetManager().GetConversation(userID)
+
+	place, ok := parseEventPlace(message, conversation.EventData)
+	if !ok {
+		sendInvalidEventPlace(ctx, b, chatID)
+		return
+	}
+	place.applyTo(conversation.EventData)
+
+	advanceEventDialog(ctx, b, userID, chatID, "✅ Місце збережено!\n\n")
+}
 
+// eventPlace is where an event takes place: an address, optionally pinned
+// on the map.
+type eventPlace struct {
+	location  *string
+	latitude  *float64
+	longitude *float64
+}
+
+func (p eventPlace) applyTo(event *internalModels.Event) {
+	event.Location = p.location
+	event.Latitude = p.latitude
+	event.Longitude = p.longitude
+}
+
+// parseEventPlace reads the place from a typed address, a venue or a
+// location sent from the attachment menu. A bare location only pins the
+// address of current on the map; without an address the coordinates are
+// used instead. ok is false when the message has none of these or its
+// coordinates are out of range.
+func parseEventPlace(message *models.Message, current *internalModels.Event) (place eventPlace, ok bool) {
+	var address string
+	var pin *models.Location
+
+	switch {
+	case message.Venue != nil:
+		address = message.Venue.Address
+		if title := message.Venue.Title; title != "" && title != address {
+			address = title + ", " + address
+		}
+		pin = &message.Venue.Location
+	case message.Location != nil:
+		pin = message.Location
+		if current.Location != nil && (!current.HasCoordinates() ||
+			*current.Location != formatCoordinates(*current.Latitude, *current.Longitude)) {
+			address = *current.Location
+		}
+		if address == "" {
+			address = formatCoordinates(pin.Latitude, pin.Longitude)
+		}
+	default:
+		text := strings.TrimSpace(message.Text)
+		if text == "" {
+			return eventPlace{}, false
+		}
+		return eventPlace{location: optionalDialogValue(text)}, true
+	}
+
+	if !validCoordinates(pin.Latitude, pin.Longitude) {
+		return eventPlace{}, false
+	}
+
+	return eventPlace{
+		location:  &address,
+		latitude:  &pin.Latitude,
+		longitude: &pin.Longitude,
+	}, true
+}
+
+func validCoordinates(latitude, longitude float64) bool {
+	return latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180
+}
+
+func formatCoordinates(latitude, longitude float64) string {
+	return fmt.Sprintf("%.5f, %.5f", latitude, longitude)
+}
+
+func sendInvalidEventPlace(ctx context.Context, b *bot.Bot, chatID int64) {
 	b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID:    chatID,
-		Text:      text,
-		ParseMode: models.ParseModeHTML,
+		ChatID: chatID,
+		Text:   "❌ Введіть адресу текстом, надішліть геопозицію чи місце або /skip щоб пропустити:",
 	})
 }
 
-func handleLocation(ctx context.Context, b *bot.Bot, userID int64, chatID int64, location string) {
-	conv := conversation.GetManager()
-	conversation := conv.GetConversation(userID)
+func handleCategory(ctx context.Context, b *bot.Bot, userID int64, chatID int64, value string) {
+	category, ok := resolveEventCategory(ctx, value)
+	if !ok {
+		sendUnknownEventCategory(ctx, b, chatID, "admin_event_step_category")
+		return
+	}
 
-	if location != "/skip" {
-		conversation.EventData.Location = &location
+	conversation := conversation.GetManager().GetConversation(userID)
+	conversation.EventData.Category = category
+
+	advanceEventDialog(ctx, b, userID, chatID, "✅ Категорія збережена!\n\n")
+}
+
+func handleRegistrationURL(ctx context.Context, b *bot.Bot, userID int64, chatID int64, url string) {
+	conversation := conversation.GetManager().GetConversation(userID)
+	conversation.EventData.RegistrationURL = optionalDialogValue(url)
+
+	advanceEventDialog(ctx, b, userID, chatID, "✅ Посилання збережено!\n\n")
+}
+
+func handleImage(ctx context.Context, b *bot.Bot, userID int64, chatID int64, message *models.Message) {
+	value := message.Text
+	if value != "/skip" {
+		value = photoFileID(message)
 	}
+	if value == "" {
+		sendInvalidEventImage(ctx, b, chatID)
+		return
+	}
+
+	conversation := conversation.GetManager().GetConversation(userID)
+	conversation.EventData.ImageFileID = optionalDialogValue(value)
 
-	conv.SetState(userID, internalModels.StateAwaitingCategory)
+	advanceEventDialog(ctx, b, userID, chatID, "✅ Постер збережено!\n\n")
+}
 
-	text := "✅ Місце збережено!\n\n" +
-		"Крок 5 з 6\n" +
-		"Введіть <b>категорію події</b>:\n\n" +
-		"Наприклад: Богослужіння, Семінар, Концерт, Молодіжка\n\n" +
-		"Або натисніть /skip щоб пропустити\n" +
-		"Для скасування натисніть /cancel"
+// photoFileID returns the file_id of the largest size of the photo in the
+// message, or an empty string if the message has no photo.
+func photoFileID(message *models.Message) string {
+	if len(message.Photo) == 0 {
+		return ""
+	}
+	return message.Photo[len(message.Photo)-1].FileID
+}
 
+func sendInvalidEventImage(ctx context.Context, b *bot.Bot, chatID int64) {
 	b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID:    chatID,
-		Text:      text,
-		ParseMode: models.ParseModeHTML,
+		ChatID: chatID,
+		Text:   "❌ Надішліть постер як фото (не файлом) або /skip щоб пропустити:",
 	})
 }
 
-func handleCategory(ctx context.Context, b *bot.Bot, userID int64, chatID int64, category string) {
+// createEvent saves the event collected by the add-event dialog and ends the
+// dialog. A draft may carry a scheduled publish time.
+func createEvent(ctx context.Context, userID int64, isPublished bool, publishAt *time.Time) (*internalModels.Event, error) {
 	conv := conversation.GetManager()
 	conversation := conv.GetConversation(userID)
+	defer conv.ClearState(userID)
+
+	event := conversation.EventData
+	event.IsPublished = isPublished
+	event.PublishAt = publishAt
+	event.CreatedAt = time.Now()
+	event.CreatedBy = userID
 
-	if category != "/skip" {
-		conversation.EventData.Category = &category
+	if err := eventRepo.Create(ctx, event); err != nil {
+		return nil, err
 	}
 
-	conv.SetState(userID, internalModels.StateAwaitingRegURL)
+	return event, nil
+}
 
-	text := "✅ Категорія збережена!\n\n" +
-		"Крок 6 з 6\n" +
-		"Введіть <b>посилання для реєстрації</b>:\n\n" +
-		"Наприклад: https://forms.google.com/...\n\n" +
-		"Або натисніть /skip щоб пропустити\n" +
-		"Для скасування натисніть /cancel"
+// eventDateFormats lists the accepted date formats for prompts and errors.
+const eventDateFormats = "• <code>25.12.2025 16:00</code>\n" +
+	"• <code>25.12.2025</code> — на весь день\n" +
+	"• <code>25.12.2025 16:00 - 18:00</code>\n" +
+	"• <code>15.07.2026 - 17.07.2026</code> — кілька днів\n" +
+	"• <code>15.07.2026 18:00 - 17.07.2026 14:00</code>"
 
+func sendInvalidEventDate(ctx context.Context, b *bot.Bot, chatID int64, err error) {
 	b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID:    chatID,
-		Text:      text,
+		ChatID: chatID,
+		Text: fmt.Sprintf("❌ %s!\n\n", capitalize(err.Error())) +
+			"Використовуйте формат:\n" +
+			eventDateFormats + "\n\n" +
+			"Спробуйте ще раз:",
 		ParseMode: models.ParseModeHTML,
 	})
 }
 
-func handleRegistrationURL(ctx context.Context, b *bot.Bot, userID int64, chatID int64, url string) {
-	conv := conversation.GetManager()
-	conversation := conv.GetConversation(userID)
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
 
-	if url != "/skip" {
-		conversation.EventData.RegistrationURL = &url
-	}
+// eventPeriod is when an event takes place, as entered by admins in Warsaw
+// time. All-day events start and end at Warsaw midnight.
+type eventPeriod struct {
+	start  time.Time
+	end    *time.Time
+	allDay bool
+}
 
-	conversation.EventData.IsPublished = true
-	conversation.EventData.CreatedAt = time.Now()
-	conversation.EventData.CreatedBy = userID
+func (p eventPeriod) applyTo(event *internalModels.Event) {
+	event.Date = p.start
+	event.EndDate = p.end
+	event.AllDay = p.allDay
+}
 
-	err := eventRepo.Create(ctx, conversation.EventData)
-	if err != nil {
-		log.Printf("Error creating event: %v", err)
-		b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ Помилка збереження події в базу даних.",
-		})
-		conv.ClearState(userID)
-		return
+// parseEventPeriod parses the start of the event, "ДД.ММ.РРРР ГГ:ХХ" or
+// "ДД.ММ.РРРР" for all-day events, optionally followed by "-" and the end: a
+// time on the same day, a date and time, or the last day of an all-day event.
+func parseEventPeriod(input string) (eventPeriod, error) {
+	const (
+		dateLayout     = "02.01.2006"
+		dateTimeLayout = "02.01.2006 15:04"
+	)
+
+	startValue, endValue := input, ""
+	if i := strings.IndexAny(input, "-–—"); i >= 0 {
+		_, size := utf8.DecodeRuneInString(input[i:])
+		startValue, endValue = input[:i], input[i+size:]
 	}
+	startValue, endValue = strings.TrimSpace(startValue), strings.TrimSpace(endValue)
 
-	summary := formatEventSummary(conversation.EventData)
-
-	conv.ClearState(userID)
+	var period eventPeriod
 
-	keyboard := keyboards.AdminPanelKeyboard()
+	start, err := clock.Parse(dateTimeLayout, startValue)
+	if err != nil {
+		start, err = clock.Parse(dateLayout, startValue)
+		if err != nil {
+			return eventPeriod{}, fmt.Errorf("неправильний формат дати")
+		}
+		period.allDay = true
+	}
+	period.start = start
 
-	b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID:      chatID,
-		Text:        summary,
-		ParseMode:   models.ParseModeHTML,
-		ReplyMarkup: keyboard,
-	})
-}
+	if endValue == "" {
+		return period, nil
+	}
 
-func parseEventDate(input string) (time.Time, error) {
-	t, err := time.Parse("02.01.2006 15:04", input)
-	if err == nil {
-		return t, nil
+	var end time.Time
+	if period.allDay {
+		end, err = clock.Parse(dateLayout, endValue)
+	} else if end, err = clock.Parse(dateTimeLayout, endValue); err != nil {
+		var endTime time.Time
+		endTime, err = time.Parse("15:04", endValue)
+		end = clock.Date(start.Year(), start.Month(), start.Day(), endTime.Hour(), endTime.Minute())
+	}
+	if err != nil {
+		return eventPeriod{}, fmt.Errorf("неправильний формат дати завершення")
 	}
 
-	t, err = time.Parse("02.01.2006", input)
-	if err == nil {
-		return t, nil
+	switch {
+	case period.allDay && end.Equal(start):
+		return period, nil
+	case !end.After(start):
+		return eventPeriod{}, fmt.Errorf("завершення має бути пізніше за початок")
 	}
 
-	return time.Time{}, fmt.Errorf("неправильний формат дати")
+	period.end = &end
+	return period, nil
 }
 
 func formatEventSummary(event *internalModels.Event) string {
-	text := "✅ <b>Подію успішно створено!</b>\n\n" +
-		fmt.Sprintf("<b>%s</b>\n", event.Title) +
-		fmt.Sprintf("📅 %s\n", formatEventDate(event.Date)) +
-		fmt.Sprintf("📝 %s\n", event.Description)
+	title := "✅ <b>Подію успішно створено та опубліковано!</b>"
+	if !event.IsPublished {
+		title = "📝 <b>Подію збережено як чернетку</b>"
+	}
+
+	text := title + "\n\n" +
+		fmt.Sprintf("<b>%s</b>\n", html.EscapeString(event.Title)) +
+		fmt.Sprintf("📅 %s\n", messages.FormatEventDate(event)) +
+		fmt.Sprintf("📝 %s\n", html.EscapeString(event.Description))
 
 	if event.Location != nil && *event.Location != "" {
-		text += fmt.Sprintf("📍 %s\n", *event.Location)
+		text += fmt.Sprintf("📍 %s\n", html.EscapeString(*event.Location))
+	}
+	if event.HasCoordinates() {
+		text += "🗺 Позначено на мапі\n"
 	}
 	if event.Category != nil && *event.Category != "" {
-		text += fmt.Sprintf("🏷 %s\n", *event.Category)
+		text += fmt.Sprintf("🏷 %s\n", html.EscapeString(*event.Category))
 	}
 	if event.RegistrationURL != nil && *event.RegistrationURL != "" {
-		text += fmt.Sprintf("🔗 %s\n", *event.RegistrationURL)
+		text += fmt.Sprintf("🔗 %s\n", html.EscapeString(*event.RegistrationURL))
+	}
+	if event.IsScheduled() {
+		text += fmt.Sprintf("⏰ Буде опубліковано: %s\n", clock.In(*event.PublishAt).Format("02.01.2006 15:04"))
 	}
 
 	text += fmt.Sprintf("\nID події: %d", event.ID)
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+func TestParseEventPeriod(t *testing.T) {
+	const layout = "02.01.2006 15:04"
+
+	tests := []struct {
+		name   string
+		input  string
+		start  string
+		end    string
+		allDay bool
+	}{
+		{name: "single time", input: "25.10.2026 16:00", start: "25.10.2026 16:00"},
+		{name: "end time", input: "25.10.2026 16:00-18:00", start: "25.10.2026 16:00", end: "25.10.2026 18:00"},
+		{name: "end time after en dash", input: "25.10.2026 16:00 – 18:00", start: "25.10.2026 16:00", end: "25.10.2026 18:00"},
+		{name: "multi-day", input: "20.11.2026 18:00 - 22.11.2026 14:00", start: "20.11.2026 18:00", end: "22.11.2026 14:00"},
+		{name: "overnight across the switch to winter time", input: "24.10.2026 22:00 — 25.10.2026 10:00", start: "24.10.2026 22:00", end: "25.10.2026 10:00"},
+		{name: "all day", input: "29.03.2026", start: "29.03.2026 00:00", allDay: true},
+		{name: "all-day camp", input: "15.07.2026 - 17.07.2026", start: "15.07.2026 00:00", end: "17.07.2026 00:00", allDay: true},
+		{name: "all day ending the same day", input: "15.07.2026-15.07.2026", start: "15.07.2026 00:00", allDay: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			period, err := parseEventPeriod(tt.input)
+			if err != nil {
+				t.Fatalf("parseEventPeriod(%q): %v", tt.input, err)
+			}
+
+			if period.start.Location() != clock.Location() {
+				t.Errorf("start %s is not in Warsaw time", period.start)
+			}
+			if got := period.start.Format(layout); got != tt.start {
+				t.Errorf("start = %s, want %s", got, tt.start)
+			}
+
+			var end string
+			if period.end != nil {
+				end = clock.In(*period.end).Format(layout)
+			}
+			if end != tt.end {
+				t.Errorf("end = %q, want %q", end, tt.end)
+			}
+
+			if period.allDay != tt.allDay {
+				t.Errorf("allDay = %v, want %v", period.allDay, tt.allDay)
+			}
+		})
+	}
+}
+
+func TestParseEventPeriodKeepsDuration(t *testing.T) {
+	// The night of the switch to winter time is an hour longer.
+	period, err := parseEventPeriod("24.10.2026 22:00 - 25.10.2026 10:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := period.end.Sub(period.start); got != 13*time.Hour {
+		t.Errorf("event lasts %s, want 13h", got)
+	}
+}
+
+func TestParseEventPeriodErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"", "неправильний формат дати"},
+		{"25/10/2026 16:00", "неправильний формат дати"},
+		{"31.02.2026", "неправильний формат дати"},
+		{"25.10.2026 16:00 - пізно", "неправильний формат дати завершення"},
+		{"15.07.2026 - 17.07.2026 12:00", "неправильний формат дати завершення"},
+		{"25.10.2026 16:00-15:00", "завершення має бути пізніше за початок"},
+		{"25.10.2026 16:00-16:00", "завершення має бути пізніше за початок"},
+		{"25.10.2026 16:00 - 24.10.2026 18:00", "завершення має бути пізніше за початок"},
+		{"17.07.2026 - 15.07.2026", "завершення має бути пізніше за початок"},
+	}
+
+	for _, tt := range tests {
+		_, err := parseEventPeriod(tt.input)
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("parseEventPeriod(%q) error = %v, want %q", tt.input, err, tt.err)
+		}
+	}
+}
+
+func TestParseEventPlace(t *testing.T) {
+	address := "вул. Прикладна 1"
+	pinnedAt := formatCoordinates(52.1, 21.1)
+	latitude, longitude := 52.1, 21.1
+
+	tests := []struct {
+		name     string
+		message  models.Message
+		current  internalModels.Event
+		location string
+		pinned   bool
+		ok       bool
+	}{
+		{
+			name:     "text",
+			message:  models.Message{Text: "  " + address + " "},
+			location: address,
+			ok:       true,
+		},
+		{
+			name:    "skip",
+			message: models.Message{Text: "/skip"},
+			ok:      true,
+		},
+		{
+			name: "venue",
+			message: models.Message{Venue: &models.Venue{
+				Title:    "Церква",
+				Address:  address,
+				Location: models.Location{Latitude: 52.2297, Longitude: 21.0122},
+			}},
+			location: "Церква, " + address,
+			pinned:   true,
+			ok:       true,
+		},
+		{
+			name:     "location pins the typed address",
+			message:  models.Message{Location: &models.Location{Latitude: 52.2297, Longitude: 21.0122}},
+			current:  internalModels.Event{Location: &address},
+			location: address,
+			pinned:   true,
+			ok:       true,
+		},
+		{
+			name:     "location without an address",
+			message:  models.Message{Location: &models.Location{Latitude: 52.2297, Longitude: 21.0122}},
+			location: "52.22970, 21.01220",
+			pinned:   true,
+			ok:       true,
+		},
+		{
+			name:     "location replaces the previous coordinates",
+			message:  models.Message{Location: &models.Location{Latitude: 52.2297, Longitude: 21.0122}},
+			current:  internalModels.Event{Location: &pinnedAt, Latitude: &latitude, Longitude: &longitude},
+			location: "52.22970, 21.01220",
+			pinned:   true,
+			ok:       true,
+		},
+		{
+			name:    "latitude out of range",
+			message: models.Message{Location: &models.Location{Latitude: 91, Longitude: 21}},
+		},
+		{
+			name:    "longitude out of range",
+			message: models.Message{Venue: &models.Venue{Address: address, Location: models.Location{Latitude: 52, Longitude: -180.5}}},
+		},
+		{
+			name:    "empty message",
+			message: models.Message{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			place, ok := parseEventPlace(&tt.message, &tt.current)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+
+			var location string
+			if place.location != nil {
+				location = *place.location
+			}
+			if location != tt.location {
+				t.Errorf("location = %q, want %q", location, tt.location)
+			}
+
+			if pinned := place.latitude != nil && place.longitude != nil; pinned != tt.pinned {
+				t.Errorf("pinned = %v, want %v", pinned, tt.pinned)
+			}
+		})
+	}
+}
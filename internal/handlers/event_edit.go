@@ -0,0 +1,540 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/conversation"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+var eventFieldLabels = map[string]string{
+	"title":            "Назва",
+	"date":             "Дата",
+	"description":      "Опис",
+	"location":         "Місце",
+	"category":         "Категорія",
+	"registration_url": "Реєстрація",
+	"report_url":       "Фотозвіт",
+	"capacity":         "Кількість місць",
+	"image":            "Постер",
+}
+
+func isEventEditState(state string) bool {
+	switch state {
+	case internalModels.StateAwaitingEditEventID,
+		internalModels.StateAwaitingEventEditValue,
+		internalModels.StateAwaitingEventEditConfirm:
+		return true
+	}
+	return false
+}
+
+// EventEditCallbackHandler handles the admin_event_edit* callbacks of the
+// one-off event editing dialog.
+func EventEditCallbackHandler(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	action, param, _ := strings.Cut(callback.Data, ":")
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+
+	switch action {
+	case "admin_event_edit":
+		if param == "" {
+			conv := conversation.GetManager()
+			conv.SetState(callback.From.ID, internalModels.StateAwaitingEditEventID)
+			text = "✏️ <b>Редагування події</b>\n\n" +
+				"Введіть <b>ID події</b>, яку потрібно змінити:\n\n" +
+				"Ви можете побачити ID в списку подій."
+			keyboard = keyboards.BackToAdminPanelKeyboard()
+			break
+		}
+		text, keyboard = getEventEditMenu(ctx, param)
+
+	case "admin_event_edit_field":
+		handleEventEditField(ctx, b, callback, param)
+		return
+
+	case "admin_event_edit_category":
+		handleEventEditCategory(ctx, b, callback, param)
+		return
+
+	case "admin_event_edit_save":
+		text, keyboard = saveEventEdit(ctx, b, callback.From.ID)
+
+	case "admin_event_edit_cancel":
+		conversation.GetManager().ClearState(callback.From.ID)
+		text = "❌ Редагування скасовано."
+		keyboard = keyboards.AdminEventsListKeyboard()
+
+	default:
+		log.Printf("EventEditCallbackHandler: unknown command '%s'", callback.Data)
+		text = "Невідома команда"
+		keyboard = keyboards.AdminPanelKeyboard()
+	}
+
+	if callback.Message.Message == nil {
+		log.Printf("Error: callback message is nil")
+		return
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      callback.Message.Message.Chat.ID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+}
+
+func HandleEventEditMessage(ctx context.Context, b *bot.Bot, update *models.Update) {
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+	value := strings.TrimSpace(update.Message.Text)
+
+	conv := conversation.GetManager()
+
+	switch conv.GetState(userID) {
+	case internalModels.StateAwaitingEditEventID:
+		if _, err := strconv.Atoi(value); err != nil {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   "❌ Неправильний формат ID. Введіть число.",
+			})
+			return
+		}
+
+		conv.ClearState(userID)
+
+		text, keyboard := getEventEditMenu(ctx, value)
+
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        text,
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: keyboard,
+		})
+
+	case internalModels.StateAwaitingEventEditValue:
+		conversation := conv.GetConversation(userID)
+		if conversation.EditField == "location" {
+			place, ok := parseEventPlace(update.Message, conversation.EventData)
+			if !ok {
+				sendInvalidEventPlace(ctx, b, chatID)
+				return
+			}
+			edited := *conversation.EventData
+			place.applyTo(&edited)
+			confirmEventEdit(ctx, b, userID, chatID, &edited)
+			return
+		}
+		if conversation.EditField == "image" && value != "/skip" {
+			value = photoFileID(update.Message)
+			if value == "" {
+				sendInvalidEventImage(ctx, b, chatID)
+				return
+			}
+		}
+		handleEventEditValue(ctx, b, userID, chatID, value)
+
+	case internalModels.StateAwaitingEventEditConfirm:
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        "👆 Збережіть або скасуйте зміни кнопками вище.",
+			ReplyMarkup: keyboards.EventEditConfirmKeyboard(),
+		})
+	}
+}
+
+func getEventEditMenu(ctx context.Context, param string) (string, *models.InlineKeyboardMarkup) {
+	eventID, err := strconv.Atoi(param)
+	if err != nil {
+		return "❌ Подію не знайдено.", keyboards.AdminEventsListKeyboard()
+	}
+
+	event, err := eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		log.Printf("Error getting event %d for edit: %v", eventID, err)
+		return "❌ Подію з таким ID не знайдено.", keyboards.AdminEventsListKeyboard()
+	}
+
+	text := fmt.Sprintf("✏️ <b>Редагування: %s</b>\n\n", html.EscapeString(event.Title)) +
+		messages.FormatEventDetails(event) +
+		fmt.Sprintf("Статус: %s\n", formatEventStatus(event)) +
+		fmt.Sprintf("ID: %d\n\n", event.ID) +
+		"Оберіть поле, яке потрібно змінити:"
+
+	return text, keyboards.EventEditFieldsKeyboard(event)
+}
+
+// handleEventEditField asks for the new value of a field. param has the form
+// "<event id>:<field>".
+func handleEventEditField(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, param string) {
+	userID := callback.From.ID
+	chatID := callback.Message.Message.Chat.ID
+
+	idValue, field, _ := strings.Cut(param, ":")
+
+	eventID, err := strconv.Atoi(idValue)
+	var event *internalModels.Event
+	if err == nil {
+		event, err = eventRepo.GetByID(ctx, eventID)
+	}
+	if err != nil {
+		log.Printf("Error getting event for edit: %v", err)
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            "❌ Подію не знайдено",
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	var text string
+	var keyboard models.ReplyMarkup
+
+	switch field {
+	case "title":
+		text = fmt.Sprintf("Поточна назва: <b>%s</b>\n\nВведіть нову назву:", html.EscapeString(event.Title))
+	case "date":
+		text = fmt.Sprintf("Поточна дата: <b>%s</b>\n\n", messages.FormatEventDate(event)) +
+			"Введіть нову дату в одному з форматів:\n" + eventDateFormats
+	case "description":
+		text = fmt.Sprintf("Поточний опис:\n%s\n\nВведіть новий опис:", html.EscapeString(event.Description))
+	case "location":
+		text = fmt.Sprintf("Поточне місце: <b>%s</b>\n\n", formatEventPlace(event)) +
+			"Введіть нову адресу, надішліть геопозицію чи місце через 📎 або /skip щоб очистити:"
+	case "category":
+		text = fmt.Sprintf("Поточна категорія: <b>%s</b>\n\nОберіть нову категорію кнопкою нижче або /skip щоб очистити:", formatOptionalField(event.Category))
+		keyboard = eventCategoryKeyboard(ctx, "admin_event_edit_category")
+	case "registration_url":
+		text = fmt.Sprintf("Поточне посилання: %s\n\nВведіть нове посилання або /skip щоб очистити:", formatOptionalField(event.RegistrationURL))
+	case "report_url":
+		text = fmt.Sprintf("Поточний фотозвіт: %s\n\nВведіть посилання на фотозвіт (наприклад, на альбом) або /skip щоб очистити:", formatOptionalField(event.ReportURL))
+	case "image":
+		text = fmt.Sprintf("Поточний постер: <b>%s</b>\n\nНадішліть нове фото або /skip щоб прибрати постер:", formatPoster(event.ImageFileID))
+	case "capacity":
+		text = fmt.Sprintf("Поточна кількість місць: <b>%s</b>\n\nВведіть нову кількість або 0, щоб зняти обмеження:", formatCapacity(event.Capacity))
+	default:
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+		return
+	}
+
+	conv := conversation.GetManager()
+	conv.ClearState(userID)
+	conv.SetState(userID, internalModels.StateAwaitingEventEditValue)
+
+	conversation := conv.GetConversation(userID)
+	conversation.EventData = event
+	conversation.EditField = field
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text + "\n\nДля скасування натисніть /cancel",
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+}
+
+// handleEventEditCategory takes the category picked by button
+// ("admin_event_edit_category:<id>") as if it was typed.
+func handleEventEditCategory(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, param string) {
+	userID := callback.From.ID
+	chatID := callback.Message.Message.Chat.ID
+
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+
+	if conversation == nil || conversation.State != internalModels.StateAwaitingEventEditValue || conversation.EditField != "category" {
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            "❌ Цей крок вже неактуальний",
+		})
+		return
+	}
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:    chatID,
+		MessageID: callback.Message.Message.ID,
+	})
+
+	value, ok := categoryCallbackValue(ctx, param)
+	if !ok {
+		sendUnknownEventCategory(ctx, b, chatID, "admin_event_edit_category")
+		return
+	}
+
+	handleEventEditValue(ctx, b, userID, chatID, value)
+}
+
+func handleEventEditValue(ctx context.Context, b *bot.Bot, userID int64, chatID int64, value string) {
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+
+	original := conversation.EventData
+	edited := *original
+
+	optional := func() *string {
+		if value == "/skip" {
+			return nil
+		}
+		return &value
+	}
+
+	switch conversation.EditField {
+	case "title":
+		edited.Title = value
+	case "date":
+		period, err := parseEventPeriod(value)
+		if err != nil {
+			sendInvalidEventDate(ctx, b, chatID, err)
+			return
+		}
+		period.applyTo(&edited)
+	case "description":
+		edited.Description = value
+	case "category":
+		category, ok := resolveEventCategory(ctx, value)
+		if !ok {
+			sendUnknownEventCategory(ctx, b, chatID, "admin_event_edit_category")
+			return
+		}
+		edited.Category = category
+	case "registration_url":
+		edited.RegistrationURL = optional()
+	case "report_url":
+		if value != "/skip" && !isWebURL(value) {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   "❌ Введіть посилання, що починається з http:// або https://, або /skip щоб очистити:",
+			})
+			return
+		}
+		edited.ReportURL = optional()
+	case "capacity":
+		capacity, err := strconv.Atoi(value)
+		if err != nil || capacity < 0 {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   "❌ Введіть ціле число, 0 — без обмежень:",
+			})
+			return
+		}
+		edited.Capacity = capacity
+	case "image":
+		edited.ImageFileID = optional()
+	}
+
+	confirmEventEdit(ctx, b, userID, chatID, &edited)
+}
+
+// confirmEventEdit shows the change of the edited field and asks to save it,
+// or returns to the edit menu when nothing changed.
+func confirmEventEdit(ctx context.Context, b *bot.Bot, userID int64, chatID int64, edited *internalModels.Event) {
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+	original := conversation.EventData
+
+	before := formatEventField(original, conversation.EditField)
+	after := formatEventField(edited, conversation.EditField)
+
+	unchanged := before == after
+	if conversation.EditField == "image" {
+		// Every poster is shown the same way, so compare the file IDs.
+		unchanged = formatOptionalField(original.ImageFileID) == formatOptionalField(edited.ImageFileID)
+	}
+
+	if unchanged {
+		conv.ClearState(userID)
+		text, keyboard := getEventEditMenu(ctx, strconv.Itoa(original.ID))
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        "ℹ️ Значення не змінилося.\n\n" + text,
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: keyboard,
+		})
+		return
+	}
+
+	conversation.EventData = edited
+	conv.SetState(userID, internalModels.StateAwaitingEventEditConfirm)
+
+	text := "✏️ <b>Перевірте зміни</b>\n\n" +
+		fmt.Sprintf("Подія: <b>%s</b> (ID: %d)\n", html.EscapeString(original.Title), original.ID) +
+		fmt.Sprintf("Поле: <b>%s</b>\n\n", eventFieldLabels[conversation.EditField]) +
+		fmt.Sprintf("➖ Було: <s>%s</s>\n", before) +
+		fmt.Sprintf("➕ Стане: %s\n\n", after) +
+		"Зберегти зміни?"
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboards.EventEditConfirmKeyboard(),
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+}
+
+// saveEventEdit applies the confirmed field to the current version of the
+// event, so concurrent edits of other fields are not overwritten.
+func saveEventEdit(ctx context.Context, b *bot.Bot, userID int64) (string, *models.InlineKeyboardMarkup) {
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+
+	if conversation == nil || conversation.State != internalModels.StateAwaitingEventEditConfirm {
+		return "❌ Цей крок вже неактуальний.", keyboards.AdminEventsListKeyboard()
+	}
+
+	edited := conversation.EventData
+	field := conversation.EditField
+	conv.ClearState(userID)
+
+	event, err := eventRepo.GetByID(ctx, edited.ID)
+	if err != nil {
+		log.Printf("Error getting event %d for edit: %v", edited.ID, err)
+		return "❌ Подію не знайдено.", keyboards.AdminEventsListKeyboard()
+	}
+
+	switch field {
+	case "title":
+		event.Title = edited.Title
+	case "date":
+		event.Date = edited.Date
+		event.EndDate = edited.EndDate
+		event.AllDay = edited.AllDay
+	case "description":
+		event.Description = edited.Description
+	case "location":
+		event.Location = edited.Location
+		event.Latitude = edited.Latitude
+		event.Longitude = edited.Longitude
+	case "category":
+		event.Category = edited.Category
+	case "registration_url":
+		event.RegistrationURL = edited.RegistrationURL
+	case "report_url":
+		event.ReportURL = edited.ReportURL
+	case "capacity":
+		event.Capacity = edited.Capacity
+	case "image":
+		event.ImageFileID = edited.ImageFileID
+	}
+
+	if err := eventRepo.Update(ctx, event); err != nil {
+		log.Printf("Error updating event %d: %v", event.ID, err)
+		return "❌ Помилка збереження змін.", keyboards.AdminEventsListKeyboard()
+	}
+
+	log.Printf("Event %d field %s updated by %d", event.ID, field, userID)
+
+	// More places may let people in from the waitlist.
+	if field == "capacity" {
+		promoted, err := eventRegistrationRepo.Promote(ctx, event.ID, event.Capacity)
+		if err != nil {
+			log.Printf("Error promoting waitlist of event %d: %v", event.ID, err)
+		}
+		notifyPromoted(ctx, b, event, promoted)
+	}
+
+	text, keyboard := getEventEditMenu(ctx, strconv.Itoa(event.ID))
+
+	return "✅ Зміни збережено!\n\n" + text, keyboard
+}
+
+func formatEventField(event *internalModels.Event, field string) string {
+	switch field {
+	case "title":
+		return html.EscapeString(event.Title)
+	case "date":
+		return messages.FormatEventDate(event)
+	case "description":
+		return html.EscapeString(event.Description)
+	case "location":
+		return formatEventPlace(event)
+	case "category":
+		return formatOptionalField(event.Category)
+	case "registration_url":
+		return formatOptionalField(event.RegistrationURL)
+	case "report_url":
+		return formatOptionalField(event.ReportURL)
+	case "capacity":
+		return formatCapacity(event.Capacity)
+	case "image":
+		return formatPoster(event.ImageFileID)
+	}
+	return ""
+}
+
+// formatEventPlace renders the address with the map pin, if any, so moving
+// the pin counts as a change.
+func formatEventPlace(event *internalModels.Event) string {
+	place := formatOptionalField(event.Location)
+	if !event.HasCoordinates() {
+		return place
+	}
+
+	coordinates := formatCoordinates(*event.Latitude, *event.Longitude)
+	if place == coordinates {
+		return "📌 " + coordinates
+	}
+	return fmt.Sprintf("%s (📌 %s)", place, coordinates)
+}
+
+func formatPoster(fileID *string) string {
+	if fileID == nil || *fileID == "" {
+		return "—"
+	}
+	return "🖼 є постер"
+}
+
+func formatCapacity(capacity int) string {
+	if capacity == 0 {
+		return "без обмежень"
+	}
+	return strconv.Itoa(capacity)
+}
+
+func formatOptionalField(value *string) string {
+	if value == nil || *value == "" {
+		return "—"
+	}
+	return html.EscapeString(*value)
+}
+
+// isWebURL reports whether value is an absolute http(s) link, the only kind
+// put into <a href> of HTML messages.
+func isWebURL(value string) bool {
+	link, err := url.Parse(value)
+	return err == nil && (link.Scheme == "http" || link.Scheme == "https") && link.Host != ""
+}
@@ -0,0 +1,25 @@
+package handlers
+
+import "testing"
+
+func TestIsWebURL(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"https://photos.example.com/album", true},
+		{"http://example.com/?a=1&b=2", true},
+		{"HTTPS://example.com", true},
+		{"photos.example.com/album", false},
+		{"javascript:alert(1)", false},
+		{"https://", false},
+		{"https://exa mple.com", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := isWebURL(tt.value); got != tt.want {
+			t.Errorf("isWebURL(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
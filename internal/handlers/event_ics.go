@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/calendar"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/ical"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+const calendarName = "Слово Віри Варшава"
+
+// The full-calendar export covers the last month and the year ahead.
+const (
+	icsExportPastDays   = 30
+	icsExportNextMonths = 12
+)
+
+// EventICSCallbackHandler handles "ics:<id>" from the event card and sends
+// the event as an .ics file that calendar apps can import.
+func EventICSCallbackHandler(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	_, param, _ := strings.Cut(callback.Data, ":")
+
+	eventID, err := strconv.Atoi(param)
+	var event *internalModels.Event
+	if err == nil {
+		event, err = eventRepo.GetByID(ctx, eventID)
+	}
+	if err != nil || !event.IsVisible() {
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            "❌ Подію не знайдено або її вже знято з публікації.",
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	entry := calendarService.EventEntry(event)
+	data := ical.Encode("", []calendar.Entry{entry}, time.Now())
+
+	_, err = b.SendDocument(ctx, &bot.SendDocumentParams{
+		ChatID: callback.From.ID,
+		Document: &models.InputFileUpload{
+			Filename: fmt.Sprintf("event_%d.ics", event.ID),
+			Data:     bytes.NewReader(data),
+		},
+		Caption:   fmt.Sprintf("📆 <b>%s</b>\n\nВідкрийте файл, щоб додати подію до свого календаря.", html.EscapeString(event.Title)),
+		ParseMode: models.ParseModeHTML,
+	})
+	if err != nil {
+		log.Printf("Error sending .ics of event %d: %v", event.ID, err)
+	}
+}
+
+// sendCalendarICS sends all published events and recurring occurrences of
+// the export period as one .ics file.
+func sendCalendarICS(ctx context.Context, b *bot.Bot, chatID int64) {
+	now := clock.Now()
+	from := clock.StartOfDay(now).AddDate(0, 0, -icsExportPastDays)
+	to := from.AddDate(0, icsExportNextMonths, icsExportPastDays)
+
+	entries, err := calendarService.Between(ctx, from, to)
+	if err != nil {
+		log.Printf("Error getting calendar for export: %v", err)
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   fmt.Sprintf("❌ Помилка отримання календаря: %v", err),
+		})
+		return
+	}
+
+	data := ical.Encode(calendarName, entries, now)
+
+	caption := "📆 <b>Календар подій</b>\n\n" +
+		fmt.Sprintf("Період: %s — %s\n", from.Format("02.01.2006"), to.Format("02.01.2006")) +
+		fmt.Sprintf("Подій: %d\n\n", len(entries)) +
+		"Імпортуйте файл у Google Calendar, Apple Calendar або Outlook."
+
+	_, err = b.SendDocument(ctx, &bot.SendDocumentParams{
+		ChatID: chatID,
+		Document: &models.InputFileUpload{
+			Filename: "slowo-wiary-calendar.ics",
+			Data:     bytes.NewReader(data),
+		},
+		Caption:     caption,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboards.BackToAdminPanelKeyboard(),
+	})
+	if err != nil {
+		log.Printf("Error sending calendar .ics: %v", err)
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   fmt.Sprintf("❌ Помилка відправки файлу: %v", err),
+		})
+		return
+	}
+
+	log.Printf("✅ Calendar exported to chat %d (%d entries)", chatID, len(entries))
+}
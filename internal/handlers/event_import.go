@@ -0,0 +1,570 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/conversation"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/ical"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+const maxImportFileSize = 1 << 20
+
+// importColumns maps the accepted CSV header names to event fields.
+var importColumns = map[string]string{
+	"назва":            "title",
+	"title":            "title",
+	"дата":             "date",
+	"date":             "date",
+	"час":              "time",
+	"time":             "time",
+	"кінець":           "end",
+	"завершення":       "end",
+	"end":              "end",
+	"час завершення":   "end_time",
+	"end_time":         "end_time",
+	"опис":             "description",
+	"description":      "description",
+	"місце":            "location",
+	"location":         "location",
+	"категорія":        "category",
+	"category":         "category",
+	"реєстрація":       "registration_url",
+	"registration_url": "registration_url",
+	"фотозвіт":         "report_url",
+	"report_url":       "report_url",
+}
+
+// importDefaultColumns is the column order of CSV files without a header.
+var importDefaultColumns = []string{"title", "date", "description", "location", "category", "registration_url"}
+
+// importFailure is a row of the import file that will not be created.
+type importFailure struct {
+	row    string
+	reason string
+}
+
+// EventImportCallbackHandler handles the admin_event_import* callbacks of the
+// bulk import from CSV or .ics files.
+func EventImportCallbackHandler(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	userID := callback.From.ID
+	conv := conversation.GetManager()
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+
+	switch callback.Data {
+	case "admin_event_import":
+		conv.ClearState(userID)
+		conv.SetState(userID, internalModels.StateAwaitingImportFile)
+		text = "📥 <b>Імпорт подій з файлу</b>\n\n" +
+			"Надішліть документ <b>.csv</b> або <b>.ics</b>.\n\n" +
+			"<b>CSV</b> — перший рядок із заголовками:\n" +
+			"<code>Назва;Дата;Час;Опис;Місце;Категорія;Реєстрація</code>\n" +
+			"Обов'язкові лише назва та дата. Дата — <code>ДД.ММ.РРРР</code> або <code>ДД.ММ.РРРР ГГ:ХХ</code>, " +
+			"роздільник — кома або крапка з комою.\n\n" +
+			"<b>.ics</b> — експорт з Google Calendar, Apple Calendar чи Outlook.\n\n" +
+			"Перед збереженням ви побачите попередній перегляд.\n\n" +
+			"Для скасування натисніть /cancel"
+		keyboard = keyboards.BackToAdminPanelKeyboard()
+
+	case "admin_event_import_draft", "admin_event_import_publish":
+		text, keyboard = saveEventImport(ctx, userID, callback.Data == "admin_event_import_publish")
+
+	case "admin_event_import_cancel":
+		conv.ClearState(userID)
+		text = "❌ Імпорт скасовано."
+		keyboard = keyboards.AdminEventsListKeyboard()
+
+	default:
+		log.Printf("EventImportCallbackHandler: unknown command '%s'", callback.Data)
+		text = "Невідома команда"
+		keyboard = keyboards.AdminPanelKeyboard()
+	}
+
+	if callback.Message.Message == nil {
+		log.Printf("Error: callback message is nil")
+		return
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      callback.Message.Message.Chat.ID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+	})
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+}
+
+// HandleEventImportMessage handles messages during the import: a document is
+// parsed and previewed (a new one replaces the previous preview), anything
+// else gets a hint.
+func HandleEventImportMessage(ctx context.Context, b *bot.Bot, update *models.Update) {
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+	document := update.Message.Document
+
+	conv := conversation.GetManager()
+
+	if document == nil {
+		text := "📎 Надішліть файл .csv або .ics як документ або натисніть /cancel."
+		if conv.GetState(userID) == internalModels.StateAwaitingImportConfirm {
+			text = "👆 Підтвердьте або скасуйте імпорт кнопками вище."
+		}
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		return
+	}
+
+	if document.FileSize > maxImportFileSize {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   "❌ Файл завеликий. Максимальний розмір — 1 МБ.",
+		})
+		return
+	}
+
+	data, err := downloadDocument(ctx, b, document)
+	if err != nil {
+		log.Printf("Error downloading import file: %v", err)
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   "❌ Не вдалося завантажити файл. Спробуйте ще раз.",
+		})
+		return
+	}
+
+	events, failures, err := parseImportFile(document.FileName, data)
+	if err != nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   fmt.Sprintf("❌ Не вдалося обробити файл: %s\n\nНадішліть інший файл або натисніть /cancel.", err),
+		})
+		return
+	}
+
+	events, failures = skipExistingEvents(ctx, events, failures)
+	unknownCategories := matchImportCategories(ctx, events)
+
+	conv.SetState(userID, internalModels.StateAwaitingImportConfirm)
+	conv.GetConversation(userID).ImportEvents = events
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        formatImportPreview(document.FileName, events, failures, unknownCategories),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboards.EventImportConfirmKeyboard(len(events)),
+	})
+}
+
+func downloadDocument(ctx context.Context, b *bot.Bot, document *models.Document) ([]byte, error) {
+	file, err := b.GetFile(ctx, &bot.GetFileParams{FileID: document.FileID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.FileDownloadLink(file), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download file: status %s", resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxImportFileSize+1))
+}
+
+// parseImportFile turns the file into events by its extension. Errors are
+// about the file as a whole and are shown to the admin.
+func parseImportFile(fileName string, data []byte) ([]*internalModels.Event, []importFailure, error) {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".csv", ".txt":
+		return parseImportCSV(data)
+	case ".ics":
+		return parseImportICS(data)
+	}
+	return nil, nil, fmt.Errorf("непідтримуваний формат, потрібен .csv або .ics")
+}
+
+func parseImportCSV(data []byte) ([]*internalModels.Event, []importFailure, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	// lines keeps the file line of every record for error reports.
+	var records [][]string
+	var lines []int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("некоректний CSV: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("файл порожній")
+	}
+
+	columns, hasHeader := importHeader(records[0])
+	if hasHeader {
+		records, lines = records[1:], lines[1:]
+	}
+
+	var events []*internalModels.Event
+	var failures []importFailure
+
+	for i, record := range records {
+		values := make(map[string]string)
+		empty := true
+		for j, value := range record {
+			value = strings.TrimSpace(value)
+			if j < len(columns) && columns[j] != "" {
+				values[columns[j]] = value
+			}
+			if value != "" {
+				empty = false
+			}
+		}
+		if empty {
+			continue
+		}
+
+		row := fmt.Sprintf("Рядок %d", lines[i])
+
+		if values["title"] == "" {
+			failures = append(failures, importFailure{row, "немає назви"})
+			continue
+		}
+
+		dateValue := strings.TrimSpace(values["date"] + " " + values["time"])
+		if end := strings.TrimSpace(values["end"] + " " + values["end_time"]); end != "" {
+			dateValue += " - " + end
+		}
+
+		period, err := parseEventPeriod(dateValue)
+		if err != nil {
+			failures = append(failures, importFailure{row, fmt.Sprintf("%v «%s»", err, dateValue)})
+			continue
+		}
+
+		if report := values["report_url"]; report != "" && !isWebURL(report) {
+			failures = append(failures, importFailure{row, fmt.Sprintf("неправильне посилання на фотозвіт «%s»", report)})
+			continue
+		}
+
+		event := &internalModels.Event{
+			Title:           values["title"],
+			Description:     values["description"],
+			Location:        optionalImportValue(values["location"]),
+			Category:        optionalImportValue(values["category"]),
+			RegistrationURL: optionalImportValue(values["registration_url"]),
+			ReportURL:       optionalImportValue(values["report_url"]),
+		}
+		period.applyTo(event)
+		events = append(events, event)
+	}
+
+	return events, failures, nil
+}
+
+// importHeader maps the first CSV record to fields. Without a title or date
+// column it is treated as data in the default column order.
+func importHeader(record []string) ([]string, bool) {
+	columns := make([]string, len(record))
+	known := make(map[string]bool)
+
+	for i, name := range record {
+		field := importColumns[strings.ToLower(strings.TrimSpace(name))]
+		columns[i] = field
+		known[field] = true
+	}
+
+	if !known["title"] && !known["date"] {
+		return importDefaultColumns, false
+	}
+	return columns, true
+}
+
+func parseImportICS(data []byte) ([]*internalModels.Event, []importFailure, error) {
+	entries, err := ical.Decode(data, clock.Location())
+	if err != nil {
+		return nil, nil, fmt.Errorf("некоректний .ics: %v", err)
+	}
+
+	var events []*internalModels.Event
+	var failures []importFailure
+
+	for _, entry := range entries {
+		row := fmt.Sprintf("Рядок %d", entry.Line)
+		if entry.Summary != "" {
+			row = fmt.Sprintf("«%s» (рядок %d)", entry.Summary, entry.Line)
+		}
+
+		switch {
+		case entry.Err != nil:
+			failures = append(failures, importFailure{row, icsDateFailure(entry.Err)})
+			continue
+		case entry.Summary == "":
+			failures = append(failures, importFailure{row, "немає назви"})
+			continue
+		case entry.Recurring:
+			failures = append(failures, importFailure{row, "подія повторюється — додайте її як регулярну"})
+			continue
+		}
+
+		events = append(events, &internalModels.Event{
+			Title:           entry.Summary,
+			Description:     entry.Description,
+			Location:        optionalImportValue(entry.Location),
+			Category:        optionalImportValue(entry.Categories),
+			RegistrationURL: optionalImportValue(entry.URL),
+		})
+		importPeriod(entry).applyTo(events[len(events)-1])
+	}
+
+	return events, failures, nil
+}
+
+// icsDateFailure explains which date of the .ics event is missing or
+// malformed.
+func icsDateFailure(err error) string {
+	var propertyErr *ical.PropertyError
+	if !errors.As(err, &propertyErr) {
+		return "некоректна подія"
+	}
+
+	switch {
+	case propertyErr.Property == "DTEND":
+		return fmt.Sprintf("неправильна дата завершення (DTEND «%s»)", propertyErr.Value)
+	case propertyErr.Value == "":
+		return "немає дати початку (DTSTART)"
+	default:
+		return fmt.Sprintf("неправильна дата початку (DTSTART «%s»)", propertyErr.Value)
+	}
+}
+
+// importPeriod converts the start and end of the .ics event to an event
+// period. All-day dates are taken as Warsaw days, whatever zone they came in.
+func importPeriod(entry ical.Event) eventPeriod {
+	if entry.AllDay {
+		period := eventPeriod{
+			start:  clock.Date(entry.Start.Year(), entry.Start.Month(), entry.Start.Day(), 0, 0),
+			allDay: true,
+		}
+		if !entry.End.IsZero() {
+			// DTEND of all-day events is the day after the last one.
+			last := entry.End.AddDate(0, 0, -1)
+			last = clock.Date(last.Year(), last.Month(), last.Day(), 0, 0)
+			if last.After(period.start) {
+				period.end = &last
+			}
+		}
+		return period
+	}
+
+	period := eventPeriod{start: clock.In(entry.Start).Truncate(time.Minute)}
+	if !entry.End.IsZero() {
+		if end := clock.In(entry.End).Truncate(time.Minute); end.After(period.start) {
+			period.end = &end
+		}
+	}
+	return period
+}
+
+func optionalImportValue(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// skipExistingEvents moves events that already exist (same title and date)
+// or repeat within the file to the failures, so re-importing a file does not
+// create duplicates.
+func skipExistingEvents(ctx context.Context, events []*internalModels.Event, failures []importFailure) ([]*internalModels.Event, []importFailure) {
+	existing, err := eventRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Error getting events for import: %v", err)
+	}
+
+	key := func(event *internalModels.Event) string {
+		return strings.ToLower(event.Title) + "|" + clock.In(event.Date).Format("02.01.2006 15:04")
+	}
+
+	seen := make(map[string]bool)
+	for i := range existing {
+		seen[key(&existing[i])] = true
+	}
+
+	var unique []*internalModels.Event
+	for _, event := range events {
+		if seen[key(event)] {
+			failures = append(failures, importFailure{
+				fmt.Sprintf("«%s»", event.Title),
+				fmt.Sprintf("подія на %s вже є", messages.FormatEventDate(event)),
+			})
+			continue
+		}
+		seen[key(event)] = true
+		unique = append(unique, event)
+	}
+
+	return unique, failures
+}
+
+// matchImportCategories replaces the imported categories with the managed
+// ones of the same name. Unknown categories are cleared and returned, so the
+// admin can add them before importing again.
+func matchImportCategories(ctx context.Context, events []*internalModels.Event) []string {
+	var unknown []string
+	seen := make(map[string]bool)
+
+	for _, event := range events {
+		if event.Category == nil {
+			continue
+		}
+		value := *event.Category
+		event.Category = nil
+
+		// .ics files may list several comma-separated categories; the first
+		// known one is used.
+		for _, name := range append([]string{value}, strings.Split(value, ",")...) {
+			if category, ok := resolveEventCategory(ctx, strings.TrimSpace(name)); ok && category != nil {
+				event.Category = category
+				break
+			}
+		}
+
+		if event.Category == nil && !seen[strings.ToLower(value)] {
+			seen[strings.ToLower(value)] = true
+			unknown = append(unknown, value)
+		}
+	}
+
+	return unknown
+}
+
+func formatImportPreview(fileName string, events []*internalModels.Event, failures []importFailure, unknownCategories []string) string {
+	text := "📥 <b>Попередній перегляд імпорту</b>\n\n" +
+		fmt.Sprintf("Файл: %s\n", html.EscapeString(fileName)) +
+		fmt.Sprintf("✅ Буде створено: <b>%d</b>\n", len(events)) +
+		fmt.Sprintf("⚠️ Пропущено: <b>%d</b>\n\n", len(failures))
+
+	if len(unknownCategories) > 0 {
+		text += fmt.Sprintf("🏷 Невідомі категорії — події буде збережено без них: %s\n\n",
+			html.EscapeString(strings.Join(unknownCategories, ", ")))
+	}
+
+	var eventLines, failureLines []string
+
+	for _, event := range events {
+		eventLines = append(eventLines, fmt.Sprintf("• %s — %s",
+			messages.FormatEventDate(event), html.EscapeString(event.Title)))
+	}
+	for _, failure := range failures {
+		failureLines = append(failureLines, fmt.Sprintf("• %s: %s",
+			html.EscapeString(failure.row), html.EscapeString(failure.reason)))
+	}
+
+	// Failures are listed first: they are what the admin has to act on.
+	if len(failureLines) > 0 {
+		text += "<b>Не буде імпортовано:</b>\n" + joinImportLines(failureLines, 1500) + "\n\n"
+	}
+	if len(eventLines) > 0 {
+		text += "<b>Події:</b>\n" + joinImportLines(eventLines, 3600-len(text)) + "\n\n"
+		text += "Зберегти події?"
+	} else {
+		text += "Немає подій для імпорту. Виправте файл і надішліть його ще раз або натисніть /cancel."
+	}
+
+	return text
+}
+
+// joinImportLines joins lines up to limit bytes, summarising the rest.
+func joinImportLines(lines []string, limit int) string {
+	var text string
+	for i, line := range lines {
+		if len(text)+len(line) > limit {
+			return text + fmt.Sprintf("…і ще %d", len(lines)-i)
+		}
+		text += line + "\n"
+	}
+	return strings.TrimSuffix(text, "\n")
+}
+
+func saveEventImport(ctx context.Context, userID int64, isPublished bool) (string, *models.InlineKeyboardMarkup) {
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+
+	if conversation == nil || conversation.State != internalModels.StateAwaitingImportConfirm || len(conversation.ImportEvents) == 0 {
+		return "❌ Цей крок вже неактуальний.", keyboards.AdminEventsListKeyboard()
+	}
+
+	events := conversation.ImportEvents
+	conv.ClearState(userID)
+
+	now := time.Now()
+	for _, event := range events {
+		event.IsPublished = isPublished
+		event.CreatedAt = now
+		event.CreatedBy = userID
+	}
+
+	if err := eventRepo.CreateMany(ctx, events); err != nil {
+		log.Printf("Error importing events: %v", err)
+		return "❌ Помилка збереження. Жодну подію не створено.", keyboards.AdminEventsListKeyboard()
+	}
+
+	log.Printf("✅ %d events imported by %d (published: %v)", len(events), userID, isPublished)
+
+	status := "як чернетки"
+	if isPublished {
+		status = "та опубліковано"
+	}
+
+	text := fmt.Sprintf("✅ Імпортовано %s %s.", messages.Plural(len(events), "подію", "події", "подій"), status)
+
+	return text, keyboards.AdminEventsListKeyboard()
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+// TestParseImportCSVExportLayout reads back the file the retention job
+// sends before deleting events, see TestEventsCSV in the scheduler package.
+func TestParseImportCSVExportLayout(t *testing.T) {
+	data := "\ufeff" +
+		"Назва,Дата,Час,Кінець,Час завершення,Опис,Місце,Категорія,Реєстрація,Фотозвіт\n" +
+		"Молодіжна зустріч,25.10.2026,18:00,,20:30,\"Хвала, молитва\",Зал,Молодь,https://example.com/r,https://example.com/photos\n" +
+		"Табір,15.07.2026,,17.07.2026,,,,,,\n" +
+		"Конференція,20.11.2026,18:00,22.11.2026,14:00,,,,,\n" +
+		"День подяки,29.03.2026,,,,,,,,\n"
+
+	events, failures, err := parseImportCSV([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(failures) > 0 {
+		t.Fatalf("failures: %+v", failures)
+	}
+
+	want := []string{
+		"Молодіжна зустріч | 25.10.2026 18:00–20:30 | Хвала, молитва | Зал | Молодь | https://example.com/r | https://example.com/photos",
+		"Табір | 15.07.2026 – 17.07.2026 |  |  |  |  | ",
+		"Конференція | 20.11.2026 18:00 – 22.11.2026 14:00 |  |  |  |  | ",
+		"День подяки | 29.03.2026 |  |  |  |  | ",
+	}
+	if got := describeImported(events); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("imported events:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// 18:00 on the day of the switch to winter time is 17:00 UTC.
+	if got := events[0].Date.UTC(); !got.Equal(time.Date(2026, time.October, 25, 17, 0, 0, 0, time.UTC)) {
+		t.Errorf("start = %s UTC", got)
+	}
+	if !events[1].AllDay || events[0].AllDay {
+		t.Errorf("all-day flags = %v, %v", events[0].AllDay, events[1].AllDay)
+	}
+}
+
+func TestParseImportCSVWithoutHeader(t *testing.T) {
+	// Excel with a Polish locale separates columns with semicolons.
+	data := "Молитва;01.11.2026 19:00;Опис; вул. Прикладна 1;Молодь;https://example.com\r\n" +
+		";;;;;\r\n" +
+		"Служіння;08.11.2026 10:00\r\n"
+
+	events, failures, err := parseImportCSV([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(failures) > 0 {
+		t.Fatalf("failures: %+v", failures)
+	}
+
+	want := []string{
+		"Молитва | 01.11.2026 19:00 | Опис | вул. Прикладна 1 | Молодь | https://example.com | ",
+		"Служіння | 08.11.2026 10:00 |  |  |  |  | ",
+	}
+	if got := describeImported(events); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("imported events:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestParseImportCSVFailures(t *testing.T) {
+	data := "Назва,Дата,Час,Кінець,Час завершення,Фотозвіт\n" +
+		",01.11.2026,19:00,,,\n" +
+		"Без дати,,,,,\n" +
+		"Погана дата,31.02.2026,19:00,,,\n" +
+		"Кінець раніше,01.11.2026,19:00,,18:00,\n" +
+		"Поганий фотозвіт,01.11.2026,19:00,,,photos.example.com/album\n" +
+		"Добра,01.11.2026,19:00,,21:00,https://photos.example.com/album?a=1&b=2\n"
+
+	events, failures, err := parseImportCSV([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Title != "Добра" {
+		t.Errorf("imported %d events, want only «Добра»", len(events))
+	}
+
+	want := []importFailure{
+		{"Рядок 2", "немає назви"},
+		{"Рядок 3", "неправильний формат дати «»"},
+		{"Рядок 4", "неправильний формат дати «31.02.2026 19:00»"},
+		{"Рядок 5", "завершення має бути пізніше за початок «01.11.2026 19:00 - 18:00»"},
+		{"Рядок 6", "неправильне посилання на фотозвіт «photos.example.com/album»"},
+	}
+	if len(failures) != len(want) {
+		t.Fatalf("failures = %+v, want %+v", failures, want)
+	}
+	for i := range want {
+		if failures[i] != want[i] {
+			t.Errorf("failure %d = %+v, want %+v", i, failures[i], want[i])
+		}
+	}
+}
+
+func TestParseImportCSVErrors(t *testing.T) {
+	for _, data := range []string{"", "\ufeff", "\"незакрита лапка\n"} {
+		if _, _, err := parseImportCSV([]byte(data)); err == nil {
+			t.Errorf("parseImportCSV(%q) succeeded", data)
+		}
+	}
+}
+
+func TestParseImportICS(t *testing.T) {
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"SUMMARY:Вечір хвали",
+		"DTSTART;TZID=Europe/Warsaw:20261025T180000",
+		"DTEND;TZID=Europe/Warsaw:20261025T203000",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Табір",
+		"DTSTART;VALUE=DATE:20260715",
+		"DTEND;VALUE=DATE:20260718",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Поганий кінець",
+		"DTSTART:20261101T180000Z",
+		"DTEND:завтра",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Без початку",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Щотижня",
+		"DTSTART:20261101T100000",
+		"RRULE:FREQ=WEEKLY",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20261101T100000",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, failures, err := parseImportICS([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"Вечір хвали | 25.10.2026 18:00–20:30 |  |  |  |  | ",
+		"Табір | 15.07.2026 – 17.07.2026 |  |  |  |  | ",
+	}
+	if got := describeImported(events); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("imported events:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	wantFailures := []importFailure{
+		{"«Поганий кінець» (рядок 12)", "неправильна дата завершення (DTEND «завтра»)"},
+		{"«Без початку» (рядок 17)", "немає дати початку (DTSTART)"},
+		{"«Щотижня» (рядок 20)", "подія повторюється — додайте її як регулярну"},
+		{"Рядок 25", "немає назви"},
+	}
+	if len(failures) != len(wantFailures) {
+		t.Fatalf("failures = %+v, want %+v", failures, wantFailures)
+	}
+	for i := range wantFailures {
+		if failures[i] != wantFailures[i] {
+			t.Errorf("failure %d = %+v, want %+v", i, failures[i], wantFailures[i])
+		}
+	}
+}
+
+func TestImportPeriodAllDayInOtherZone(t *testing.T) {
+	data := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:Свято\r\nDTSTART;VALUE=DATE:20260329\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+
+	events, _, err := parseImportICS([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := clock.Date(2026, time.March, 29, 0, 0); !events[0].Date.Equal(want) || !events[0].AllDay {
+		t.Errorf("Date = %s (all day: %v), want %s", events[0].Date, events[0].AllDay, want)
+	}
+}
+
+// describeImported renders the fields set by the import, one line per event.
+func describeImported(events []*internalModels.Event) []string {
+	optional := func(value *string) string {
+		if value == nil {
+			return ""
+		}
+		return *value
+	}
+
+	var lines []string
+	for _, event := range events {
+		lines = append(lines, strings.Join([]string{
+			event.Title,
+			messages.FormatEventDate(event),
+			event.Description,
+			optional(event.Location),
+			optional(event.Category),
+			optional(event.RegistrationURL),
+			optional(event.ReportURL),
+		}, " | "))
+	}
+	return lines
+}
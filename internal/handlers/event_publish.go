@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/conversation"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+// EventPublishCallbackHandler handles the admin_event_pub* callbacks: the
+// final step of the add-event dialog and the publish toggles of existing
+// events. Callbacks without a parameter act on the event being created.
+func EventPublishCallbackHandler(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	action, param, _ := strings.Cut(callback.Data, ":")
+	userID := callback.From.ID
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+
+	switch action {
+	case "admin_event_pub_now", "admin_event_pub_draft":
+		if conversation.GetManager().GetState(userID) != internalModels.StateAwaitingConfirm {
+			text, keyboard = "❌ Цей крок вже неактуальний.", keyboards.AdminEventsListKeyboard()
+			break
+		}
+
+		event, err := createEvent(ctx, userID, action == "admin_event_pub_now", nil)
+		if err != nil {
+			log.Printf("Error creating event: %v", err)
+			text, keyboard = "❌ Помилка збереження події в базу даних.", keyboards.AdminPanelKeyboard()
+			break
+		}
+		text, keyboard = formatEventSummary(event), keyboards.EventCreatedKeyboard(event)
+
+	case "admin_event_pub_cancel":
+		conversation.GetManager().ClearState(userID)
+		text, keyboard = "❌ Додавання події скасовано.", keyboards.AdminPanelKeyboard()
+
+	case "admin_event_pub_schedule":
+		if !startPublishAtInput(ctx, userID, param) {
+			text, keyboard = "❌ Цей крок вже неактуальний.", keyboards.AdminEventsListKeyboard()
+			break
+		}
+		text = "⏰ <b>Запланована публікація</b>\n\n" +
+			"Введіть дату та час, коли подія має з'явитися для користувачів:\n\n" +
+			"Формат: <code>ДД.ММ.РРРР ГГ:ХХ</code>\n\n" +
+			"Для скасування натисніть /cancel"
+
+	case "admin_event_pub_on", "admin_event_pub_off":
+		eventID, err := strconv.Atoi(param)
+		if err == nil {
+			err = eventRepo.SetPublished(ctx, eventID, action == "admin_event_pub_on")
+		}
+		if err != nil {
+			log.Printf("Error changing event publish status: %v", err)
+			b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            "❌ Помилка зміни статусу",
+				ShowAlert:       true,
+			})
+			return
+		}
+		text, keyboard = getEventEditMenu(ctx, param)
+
+	default:
+		log.Printf("EventPublishCallbackHandler: unknown command '%s'", callback.Data)
+		text = "Невідома команда"
+		keyboard = keyboards.AdminPanelKeyboard()
+	}
+
+	if callback.Message.Message == nil {
+		log.Printf("Error: callback message is nil")
+		return
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      callback.Message.Message.Chat.ID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+}
+
+// startPublishAtInput switches the admin to entering a publish time, either
+// for the event being created (empty param) or for an existing draft.
+func startPublishAtInput(ctx context.Context, userID int64, param string) bool {
+	conv := conversation.GetManager()
+
+	if param == "" {
+		if conv.GetState(userID) != internalModels.StateAwaitingConfirm {
+			return false
+		}
+		conv.SetState(userID, internalModels.StateAwaitingPublishAt)
+		return true
+	}
+
+	eventID, err := strconv.Atoi(param)
+	if err != nil {
+		return false
+	}
+
+	event, err := eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		log.Printf("Error getting event %d: %v", eventID, err)
+		return false
+	}
+
+	conv.ClearState(userID)
+	conv.SetState(userID, internalModels.StateAwaitingPublishAt)
+	conv.GetConversation(userID).EventData = event
+
+	return true
+}
+
+func HandlePublishAtMessage(ctx context.Context, b *bot.Bot, update *models.Update) {
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+
+	publishAt, err := clock.Parse("02.01.2006 15:04", strings.TrimSpace(update.Message.Text))
+	if err != nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      "❌ Неправильний формат! Використовуйте <code>ДД.ММ.РРРР ГГ:ХХ</code>, наприклад <code>20.12.2025 18:00</code>:",
+			ParseMode: models.ParseModeHTML,
+		})
+		return
+	}
+
+	if !publishAt.After(clock.Now()) {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   "❌ Час публікації має бути в майбутньому. Спробуйте ще раз:",
+		})
+		return
+	}
+
+	conv := conversation.GetManager()
+	event := conv.GetConversation(userID).EventData
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+
+	if event.ID == 0 {
+		created, err := createEvent(ctx, userID, false, &publishAt)
+		if err != nil {
+			log.Printf("Error creating event: %v", err)
+			text, keyboard = "❌ Помилка збереження події в базу даних.", keyboards.AdminPanelKeyboard()
+		} else {
+			text, keyboard = formatEventSummary(created), keyboards.EventCreatedKeyboard(created)
+		}
+	} else {
+		conv.ClearState(userID)
+
+		event.IsPublished = false
+		event.PublishAt = &publishAt
+		if err := eventRepo.Update(ctx, event); err != nil {
+			log.Printf("Error scheduling event %d: %v", event.ID, err)
+			text, keyboard = "❌ Помилка збереження змін.", keyboards.AdminEventsListKeyboard()
+		} else {
+			text, keyboard = getEventEditMenu(ctx, strconv.Itoa(event.ID))
+			text = fmt.Sprintf("✅ Публікацію заплановано на %s\n\n", publishAt.Format("02.01.2006 15:04")) + text
+		}
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+}
+
+func formatEventStatus(event *internalModels.Event) string {
+	switch {
+	case event.IsPublished && event.AnnouncedAt != nil:
+		return fmt.Sprintf("✅ опубліковано, 📣 анонсовано %s", clock.In(*event.AnnouncedAt).Format("02.01.2006 15:04"))
+	case event.IsPublished:
+		return "✅ опубліковано"
+	case event.IsScheduled():
+		return fmt.Sprintf("⏰ чернетка, публікація %s", clock.In(*event.PublishAt).Format("02.01.2006 15:04"))
+	default:
+		return "📝 чернетка"
+	}
+}
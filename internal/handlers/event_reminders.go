@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/conversation"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+// EventReminderCallbackHandler handles the admin_event_reminder* callbacks of
+// the one-off event reminder settings.
+func EventReminderCallbackHandler(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	action, param, _ := strings.Cut(callback.Data, ":")
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+
+	switch action {
+	case "admin_event_reminders":
+		if param == "" {
+			conv := conversation.GetManager()
+			conv.SetState(callback.From.ID, internalModels.StateAwaitingReminderEventID)
+			text = "🔔 <b>Нагадування про подію</b>\n\n" +
+				"Введіть <b>ID події</b>, для якої потрібно налаштувати нагадування:\n\n" +
+				"Ви можете побачити ID в списку подій."
+			keyboard = keyboards.BackToAdminPanelKeyboard()
+			break
+		}
+
+		eventID, err := strconv.Atoi(param)
+		if err != nil {
+			text, keyboard = "❌ Подію не знайдено.", keyboards.AdminEventsListKeyboard()
+			break
+		}
+		text, keyboard = getEventRemindersText(ctx, eventID)
+
+	case "admin_event_reminder_toggle":
+		text, keyboard = toggleEventReminder(ctx, param)
+
+	default:
+		log.Printf("EventReminderCallbackHandler: unknown command '%s'", callback.Data)
+		text = "Невідома команда"
+		keyboard = keyboards.AdminPanelKeyboard()
+	}
+
+	if callback.Message.Message == nil {
+		log.Printf("Error: callback message is nil")
+		return
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      callback.Message.Message.Chat.ID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+}
+
+func HandleReminderEventIDMessage(ctx context.Context, b *bot.Bot, update *models.Update) {
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+
+	eventID, err := strconv.Atoi(strings.TrimSpace(update.Message.Text))
+	if err != nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   "❌ Неправильний формат ID. Введіть число.",
+		})
+		return
+	}
+
+	if _, err := eventRepo.GetByID(ctx, eventID); err != nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   "❌ Подію з таким ID не знайдено.",
+		})
+		return
+	}
+
+	conversation.GetManager().ClearState(userID)
+
+	text, keyboard := getEventRemindersText(ctx, eventID)
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+	})
+}
+
+func getEventRemindersText(ctx context.Context, eventID int) (string, *models.InlineKeyboardMarkup) {
+	event, err := eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		log.Printf("Error getting event %d: %v", eventID, err)
+		return "❌ Подію не знайдено.", keyboards.AdminEventsListKeyboard()
+	}
+
+	reminders, err := eventReminderRepo.GetByEvent(ctx, eventID)
+	if err != nil {
+		log.Printf("Error getting reminders of event %d: %v", eventID, err)
+		return "❌ Помилка отримання нагадувань.", keyboards.AdminEventsListKeyboard()
+	}
+
+	enabled := make(map[int]bool, len(reminders))
+	for _, reminder := range reminders {
+		enabled[reminder.OffsetMinutes] = true
+	}
+
+	text := "🔔 <b>Нагадування про подію</b>\n\n" +
+		fmt.Sprintf("<b>%s</b>\n", html.EscapeString(event.Title)) +
+		fmt.Sprintf("📅 %s\n\n", messages.FormatEventDate(event))
+
+	if len(reminders) == 0 {
+		text += "Нагадування не налаштовані.\n"
+	} else {
+		text += "Підписники отримають нагадування:\n"
+		for _, reminder := range reminders {
+			text += fmt.Sprintf("• %s\n", messages.FormatReminderOffset(reminder.OffsetMinutes))
+		}
+	}
+
+	if !event.IsPublished {
+		text += "\n⚠️ <i>Подія ще не опублікована — нагадування надсилаються лише для опублікованих подій.</i>\n"
+	}
+
+	text += "\nНатисніть на варіант, щоб увімкнути або вимкнути його."
+
+	return text, keyboards.EventRemindersKeyboard(eventID, enabled)
+}
+
+// toggleEventReminder switches a single reminder offset on or off. param has
+// the form "<event id>:<offset minutes>".
+func toggleEventReminder(ctx context.Context, param string) (string, *models.InlineKeyboardMarkup) {
+	idValue, offsetValue, _ := strings.Cut(param, ":")
+
+	eventID, err := strconv.Atoi(idValue)
+	if err != nil {
+		return "❌ Подію не знайдено.", keyboards.AdminEventsListKeyboard()
+	}
+
+	offsetMinutes, err := strconv.Atoi(offsetValue)
+	if err != nil {
+		return getEventRemindersText(ctx, eventID)
+	}
+
+	reminders, err := eventReminderRepo.GetByEvent(ctx, eventID)
+	if err != nil {
+		log.Printf("Error getting reminders of event %d: %v", eventID, err)
+		return "❌ Помилка отримання нагадувань.", keyboards.AdminEventsListKeyboard()
+	}
+
+	enabled := false
+	for _, reminder := range reminders {
+		if reminder.OffsetMinutes == offsetMinutes {
+			enabled = true
+			break
+		}
+	}
+
+	if enabled {
+		err = eventReminderRepo.Remove(ctx, eventID, offsetMinutes)
+	} else {
+		err = eventReminderRepo.Add(ctx, eventID, offsetMinutes)
+	}
+	if err != nil {
+		log.Printf("Error toggling reminder for event %d: %v", eventID, err)
+		return "❌ Помилка збереження нагадування.", keyboards.AdminEventsListKeyboard()
+	}
+
+	return getEventRemindersText(ctx, eventID)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+// RSVPCallbackHandler handles "rsvp:<id>" and "rsvp_cancel:<id>" from the
+// event card and redraws the card with the new registration status.
+func RSVPCallbackHandler(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	action, param, _ := strings.Cut(callback.Data, ":")
+	userID := callback.From.ID
+
+	answer := func(text string) {
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            text,
+			ShowAlert:       true,
+		})
+	}
+
+	eventID, err := strconv.Atoi(param)
+	var event *internalModels.Event
+	if err == nil {
+		event, err = eventRepo.GetByID(ctx, eventID)
+	}
+	if err != nil || !event.IsVisible() {
+		answer("❌ Подію не знайдено або її вже знято з публікації.")
+		return
+	}
+
+	var notice string
+
+	switch action {
+	case "rsvp":
+		if eventHasStarted(event) {
+			answer("❌ Реєстрацію на цю подію завершено.")
+			return
+		}
+
+		registration, err := eventRegistrationRepo.Register(ctx, event.ID, userID, event.Capacity)
+		if err != nil {
+			log.Printf("Error registering user %d to event %d: %v", userID, event.ID, err)
+			answer("❌ Помилка реєстрації. Спробуйте пізніше.")
+			return
+		}
+
+		log.Printf("User %d registered to event %d (%s)", userID, event.ID, registration.Status)
+
+		notice = "✅ Вас зареєстровано!"
+		if registration.Status == internalModels.RegistrationWaitlist {
+			notice = "⏳ Місць немає — вас додано до листа очікування."
+		}
+
+	case "rsvp_cancel":
+		promoted, err := eventRegistrationRepo.Cancel(ctx, event.ID, userID, event.Capacity)
+		if err != nil {
+			log.Printf("Error cancelling registration of user %d to event %d: %v", userID, event.ID, err)
+			answer("❌ Помилка скасування. Спробуйте пізніше.")
+			return
+		}
+
+		log.Printf("User %d cancelled registration to event %d", userID, event.ID)
+		notifyPromoted(ctx, b, event, promoted)
+
+		notice = "🚫 Реєстрацію скасовано."
+
+	default:
+		log.Printf("RSVPCallbackHandler: unknown command '%s'", callback.Data)
+		answer("Невідома команда")
+		return
+	}
+
+	if callback.Message.Message == nil {
+		log.Printf("Error: callback message is nil")
+		return
+	}
+
+	text, keyboard, poster := getEventCard(ctx, b, userID, param)
+	updateEventCard(ctx, b, callback.Message.Message, text, keyboard, poster)
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+		Text:            notice,
+	})
+}
+
+// notifyPromoted tells users moved up from the waitlist that they now have
+// a place at the event.
+func notifyPromoted(ctx context.Context, b *bot.Bot, event *internalModels.Event, promoted []internalModels.EventRegistration) {
+	for _, registration := range promoted {
+		text := "🎉 <b>Звільнилося місце!</b>\n\n" +
+			fmt.Sprintf("Вас переведено з листа очікування на подію <b>%s</b> (%s).\n\n", html.EscapeString(event.Title), messages.FormatEventDate(event)) +
+			"Якщо ви не зможете прийти, скасуйте реєстрацію на картці події, щоб місце отримав хтось інший."
+
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    registration.UserID,
+			Text:      text,
+			ParseMode: models.ParseModeHTML,
+		})
+		if err != nil {
+			log.Printf("Failed to notify user %d about promotion to event %d: %v", registration.UserID, event.ID, err)
+		}
+	}
+}
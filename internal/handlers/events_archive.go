@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+// archiveMaxLength keeps a month of past events below Telegram's 4096
+// character limit.
+const archiveMaxLength = 3800
+
+// getPastEvents renders one month of the past events users see: published
+// events that are not archived yet, with their photo reports. month is
+// "YYYY-MM", or empty for the most recent month.
+func getPastEvents(ctx context.Context, month string) (string, *models.InlineKeyboardMarkup) {
+	events, err := eventRepo.GetPast(ctx)
+	if err != nil {
+		log.Printf("Error getting past events: %v", err)
+		return "❌ Помилка отримання подій. Спробуйте пізніше.", keyboards.BackToEventsKeyboard()
+	}
+
+	var visible []internalModels.Event
+	for _, event := range events {
+		if event.IsVisible() {
+			visible = append(visible, event)
+		}
+	}
+
+	if len(visible) == 0 {
+		return "📚 <b>Минулі події</b>\n\nМинулих подій поки немає.", keyboards.PastEventsKeyboard(time.Time{}, time.Time{})
+	}
+
+	selected, inMonth, prev, next := archiveMonth(visible, month)
+
+	title := fmt.Sprintf("📚 <b>Минулі події · %s %d</b>\n", messages.MonthName(selected.Month()), selected.Year())
+	text := formatArchiveEntries(title, inMonth, func(event *internalModels.Event) string {
+		entry := fmt.Sprintf("<b>%s</b>\n", html.EscapeString(event.Title)) +
+			fmt.Sprintf("📅 %s\n", messages.FormatEventDate(event))
+		if event.Location != nil && *event.Location != "" {
+			entry += fmt.Sprintf("📍 %s\n", html.EscapeString(*event.Location))
+		}
+		if event.ReportURL != nil && *event.ReportURL != "" {
+			entry += fmt.Sprintf("📸 <a href=\"%s\">Фотозвіт</a>\n", html.EscapeString(*event.ReportURL))
+		}
+		return entry
+	})
+
+	return text, keyboards.PastEventsKeyboard(prev, next)
+}
+
+// getEventsArchive renders one month of past events for admins, drafts and
+// archived events included. month is "YYYY-MM", or empty for the most
+// recent month.
+func getEventsArchive(ctx context.Context, month string) (string, *models.InlineKeyboardMarkup) {
+	events, err := eventRepo.GetPast(ctx)
+	if err != nil {
+		log.Printf("Error getting past events: %v", err)
+		return "❌ Помилка отримання подій з бази даних", keyboards.AdminEventsListKeyboard()
+	}
+
+	if len(events) == 0 {
+		return "🗄 <b>Архів подій</b>\n\nМинулих подій немає.", keyboards.AdminArchiveKeyboard(time.Time{}, time.Time{})
+	}
+
+	counts, err := eventRegistrationRepo.Counts(ctx)
+	if err != nil {
+		log.Printf("Error getting registration counts: %v", err)
+	}
+
+	selected, inMonth, prev, next := archiveMonth(events, month)
+
+	title := fmt.Sprintf("🗄 <b>Архів подій · %s %d</b>\n", messages.MonthName(selected.Month()), selected.Year())
+	text := formatArchiveEntries(title, inMonth, func(event *internalModels.Event) string {
+		status := "✅"
+		if !event.IsPublished {
+			status = "📝"
+		}
+
+		entry := fmt.Sprintf("%s <b>%s</b>", status, html.EscapeString(event.Title))
+		if event.ArchivedAt != nil {
+			entry += " 🗄"
+		}
+		entry += "\n" +
+			fmt.Sprintf("📅 %s\n", messages.FormatEventDate(event)) +
+			formatAdminAttendees(event, counts[event.ID]) + "\n"
+		if event.ReportURL != nil && *event.ReportURL != "" {
+			entry += fmt.Sprintf("📸 <a href=\"%s\">Фотозвіт</a>\n", html.EscapeString(*event.ReportURL))
+		}
+		entry += fmt.Sprintf("ID: %d\n", event.ID)
+		return entry
+	})
+
+	text += "\n💡 ✅ - опубліковано, 📝 - чернетка, 🗄 - приховано від користувачів"
+
+	return text, keyboards.AdminArchiveKeyboard(prev, next)
+}
+
+// archiveMonth picks the month to show, the requested one or the most recent
+// month with events, and returns its events together with the nearest older
+// and newer months that have events. events must be sorted newest first.
+func archiveMonth(events []internalModels.Event, month string) (selected time.Time, inMonth []internalModels.Event, prev, next time.Time) {
+	monthOf := func(event *internalModels.Event) time.Time {
+		return time.Date(event.Date.Year(), event.Date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	selected, err := time.Parse("2006-01", month)
+	if err != nil {
+		selected = monthOf(&events[0])
+	}
+
+	for i := range events {
+		current := monthOf(&events[i])
+		switch {
+		case current.Equal(selected):
+			inMonth = append(inMonth, events[i])
+		case current.Before(selected):
+			if prev.IsZero() {
+				prev = current
+			}
+		default:
+			next = current
+		}
+	}
+
+	return selected, inMonth, prev, next
+}
+
+// formatArchiveEntries lists the events of a month, oldest first, cutting
+// the list short when it gets too long for one message.
+func formatArchiveEntries(title string, events []internalModels.Event, format func(*internalModels.Event) string) string {
+	text := title
+
+	if len(events) == 0 {
+		return text + "\nУ цьому місяці подій не було.\n"
+	}
+
+	for i := len(events) - 1; i >= 0; i-- {
+		block := "\n" + format(&events[i])
+
+		if len(text)+len(block) > archiveMaxLength {
+			text += fmt.Sprintf("\n… та ще %s\n", messages.Plural(i+1, "подія", "події", "подій"))
+			break
+		}
+		text += block
+	}
+
+	return text
+}
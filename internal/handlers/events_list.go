@@ -0,0 +1,380 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+const eventsPageSize = 5
+
+// eventDeepLinkPrefix is the /start payload that opens an event card, e.g.
+// "/start event_12".
+const eventDeepLinkPrefix = "event_"
+
+var (
+	botUsername   string
+	botUsernameMu sync.Mutex
+)
+
+// getBotUsername returns the bot's username. It is cached once Telegram
+// answers, so a failed call is retried next time.
+func getBotUsername(ctx context.Context, b *bot.Bot) string {
+	botUsernameMu.Lock()
+	defer botUsernameMu.Unlock()
+
+	if botUsername != "" {
+		return botUsername
+	}
+
+	me, err := b.GetMe(ctx)
+	if err != nil {
+		log.Printf("Error getting bot info: %v", err)
+		return ""
+	}
+	botUsername = me.Username
+
+	return botUsername
+}
+
+// getEventsPage renders one page of the upcoming events list. Every entry is
+// short and opens its detail card by button. categoryID filters the list by
+// category; 0 lists all events.
+func getEventsPage(ctx context.Context, page int, categoryID int) (string, *models.InlineKeyboardMarkup) {
+	events, err := eventRepo.GetUpcoming(ctx)
+	if err != nil {
+		log.Printf("Error getting upcoming events: %v", err)
+		return messages.GetText("no_events"), keyboards.BackToMainMenuKeyboard()
+	}
+
+	if len(events) == 0 {
+		return messages.GetText("no_events"), keyboards.BackToMainMenuKeyboard()
+	}
+
+	title := "📅 <b>Найближчі події</b>\n\n"
+
+	var category *internalModels.Category
+	if categoryID != 0 {
+		category, err = categoryRepo.GetByID(ctx, categoryID)
+		if err != nil {
+			log.Printf("Error getting category %d: %v", categoryID, err)
+			return getEventsPage(ctx, page, 0)
+		}
+
+		events = eventsInCategory(events, category.Name)
+		title = fmt.Sprintf("📅 <b>Найближчі події</b> · %s\n\n", category.Label())
+
+		if len(events) == 0 {
+			return title + "У цій категорії найближчих подій немає.", keyboards.EventsPageKeyboard(nil, 0, 0, 0, category)
+		}
+	}
+
+	pages := (len(events) + eventsPageSize - 1) / eventsPageSize
+	page = max(0, min(page, pages-1))
+
+	first := page * eventsPageSize
+	last := min(first+eventsPageSize, len(events))
+	events = events[first:last]
+
+	text := title
+
+	for i := range events {
+		text += formatEventListEntry(first+i+1, &events[i]) + "\n"
+	}
+
+	text += "👇 Оберіть подію, щоб побачити подробиці"
+
+	return text, keyboards.EventsPageKeyboard(events, first, page, pages, category)
+}
+
+func eventsInCategory(events []internalModels.Event, name string) []internalModels.Event {
+	var filtered []internalModels.Event
+	for _, event := range events {
+		if event.Category != nil && strings.EqualFold(*event.Category, name) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// getEventsCategoryFilter lists the categories that have upcoming events, to
+// filter the events list by.
+func getEventsCategoryFilter(ctx context.Context) (string, *models.InlineKeyboardMarkup) {
+	events, err := eventRepo.GetUpcoming(ctx)
+	if err != nil {
+		log.Printf("Error getting upcoming events: %v", err)
+		return messages.GetText("no_events"), keyboards.BackToMainMenuKeyboard()
+	}
+
+	categories, err := categoryRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Error getting categories: %v", err)
+		return "❌ Помилка отримання категорій. Спробуйте пізніше.", keyboards.BackToEventsKeyboard()
+	}
+
+	var used []internalModels.Category
+	for _, category := range categories {
+		if len(eventsInCategory(events, category.Name)) > 0 {
+			used = append(used, category)
+		}
+	}
+
+	if len(used) == 0 {
+		return "🏷 <b>Категорії</b>\n\nУ найближчих подій ще немає категорій.", keyboards.EventsCategoryFilterKeyboard(nil)
+	}
+
+	return "🏷 <b>Оберіть категорію</b>\n\nПокажемо лише події цієї категорії:", keyboards.EventsCategoryFilterKeyboard(used)
+}
+
+func formatEventListEntry(number int, event *internalModels.Event) string {
+	text := fmt.Sprintf("<b>%d. %s</b>\n", number, html.EscapeString(event.Title)) +
+		fmt.Sprintf("📅 %s\n", messages.FormatEventDate(event))
+
+	if event.Location != nil && *event.Location != "" {
+		text += fmt.Sprintf("📍 %s\n", html.EscapeString(*event.Location))
+	}
+
+	return text
+}
+
+// getEventCard renders the event card for the user. poster is the file_id
+// of the event poster, or an empty string if the card is text only.
+func getEventCard(ctx context.Context, b *bot.Bot, userID int64, param string) (text string, keyboard *models.InlineKeyboardMarkup, poster string) {
+	eventID, err := strconv.Atoi(param)
+	if err != nil {
+		return "❌ Подію не знайдено.", keyboards.BackToEventsKeyboard(), ""
+	}
+
+	event, err := eventRepo.GetByID(ctx, eventID)
+	if err != nil || !event.IsVisible() {
+		if err != nil {
+			log.Printf("Error getting event %d: %v", eventID, err)
+		}
+		return "❌ Подію не знайдено або її вже знято з публікації.", keyboards.BackToEventsKeyboard(), ""
+	}
+
+	registration, err := eventRegistrationRepo.Get(ctx, event.ID, userID)
+	if err != nil {
+		log.Printf("Error getting registration of user %d to event %d: %v", userID, event.ID, err)
+	}
+
+	text = formatEventCard(event) + formatRegistrationStatus(ctx, event, registration)
+	keyboard = keyboards.EventCardKeyboard(event, eventShareURL(ctx, b, event), registration, !eventHasStarted(event))
+
+	if event.ImageFileID != nil {
+		poster = *event.ImageFileID
+	}
+
+	return text, keyboard, poster
+}
+
+// sendEventCard sends the event card as a new message: as a photo with the
+// card in its caption when the event has a poster, or as text otherwise.
+// Cards too long for a caption are sent as the poster followed by the text.
+func sendEventCard(ctx context.Context, b *bot.Bot, chatID int64, text string, keyboard *models.InlineKeyboardMarkup, poster string) {
+	if poster != "" {
+		if messages.FitsCaption(text) {
+			_, err := b.SendPhoto(ctx, &bot.SendPhotoParams{
+				ChatID:      chatID,
+				Photo:       &models.InputFileString{Data: poster},
+				Caption:     text,
+				ParseMode:   models.ParseModeHTML,
+				ReplyMarkup: keyboard,
+			})
+			if err == nil {
+				return
+			}
+			log.Printf("Error sending event card with poster: %v", err)
+		} else {
+			b.SendPhoto(ctx, &bot.SendPhotoParams{
+				ChatID: chatID,
+				Photo:  &models.InputFileString{Data: poster},
+			})
+		}
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+}
+
+// updateEventCard replaces the card in message with a fresh one. Telegram
+// cannot turn a text message into a photo or back, so in that case the old
+// message is deleted and the card is sent anew.
+func updateEventCard(ctx context.Context, b *bot.Bot, message *models.Message, text string, keyboard *models.InlineKeyboardMarkup, poster string) {
+	hasPhoto := len(message.Photo) > 0
+
+	switch {
+	case poster == "" && !hasPhoto:
+		b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:      message.Chat.ID,
+			MessageID:   message.ID,
+			Text:        text,
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: keyboard,
+			LinkPreviewOptions: &models.LinkPreviewOptions{
+				IsDisabled: bot.True(),
+			},
+		})
+	case poster != "" && hasPhoto && messages.FitsCaption(text):
+		b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{
+			ChatID:      message.Chat.ID,
+			MessageID:   message.ID,
+			Caption:     text,
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: keyboard,
+		})
+	default:
+		b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+			ChatID:    message.Chat.ID,
+			MessageID: message.ID,
+		})
+		sendEventCard(ctx, b, message.Chat.ID, text, keyboard, poster)
+	}
+}
+
+// EventMapCallbackHandler handles "map:<id>" from the event card and sends
+// the event location as a venue, which opens in the user's map app.
+func EventMapCallbackHandler(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	_, param, _ := strings.Cut(callback.Data, ":")
+
+	eventID, err := strconv.Atoi(param)
+	var event *internalModels.Event
+	if err == nil {
+		event, err = eventRepo.GetByID(ctx, eventID)
+	}
+	if err != nil || !event.IsVisible() || !event.HasCoordinates() {
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            "❌ Місце події не знайдено.",
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	address := formatCoordinates(*event.Latitude, *event.Longitude)
+	if event.Location != nil && *event.Location != "" {
+		address = *event.Location
+	}
+
+	_, err = b.SendVenue(ctx, &bot.SendVenueParams{
+		ChatID:    callback.From.ID,
+		Latitude:  *event.Latitude,
+		Longitude: *event.Longitude,
+		Title:     event.Title,
+		Address:   address,
+	})
+	if err != nil {
+		log.Printf("Error sending venue of event %d: %v", event.ID, err)
+	}
+}
+
+// eventHasStarted reports whether the event start is already in the past.
+func eventHasStarted(event *internalModels.Event) bool {
+	return !event.Date.After(clock.Now())
+}
+
+// formatRegistrationStatus renders the attendee count of the event and the
+// user's own registration below the event card.
+func formatRegistrationStatus(ctx context.Context, event *internalModels.Event, registration *internalModels.EventRegistration) string {
+	counts, err := eventRegistrationRepo.CountsForEvent(ctx, event.ID)
+	if err != nil {
+		log.Printf("Error getting registration counts: %v", err)
+		return ""
+	}
+
+	text := "\n" + formatAttendees(event, counts) + "\n"
+
+	switch {
+	case registration == nil:
+		if event.Capacity > 0 && counts.Going >= event.Capacity && !eventHasStarted(event) {
+			text += "⏳ Місць немає — можна записатися в лист очікування.\n"
+		}
+	case registration.Status == internalModels.RegistrationWaitlist:
+		position, err := eventRegistrationRepo.WaitlistPosition(ctx, registration)
+		if err != nil {
+			log.Printf("Error getting waitlist position: %v", err)
+		}
+		text += fmt.Sprintf("⏳ Ви в листі очікування (№%d). Ми повідомимо, якщо звільниться місце.\n", position)
+	default:
+		text += "✅ Ви зареєстровані на цю подію.\n"
+	}
+
+	return text
+}
+
+// formatAttendees renders "👥 N / capacity" with the waitlist, if any.
+func formatAttendees(event *internalModels.Event, counts internalModels.RegistrationCounts) string {
+	text := fmt.Sprintf("👥 Записалося: %d", counts.Going)
+	if event.Capacity > 0 {
+		text += fmt.Sprintf(" / %d", event.Capacity)
+	}
+	if counts.Waitlist > 0 {
+		text += fmt.Sprintf(" (+%d в очікуванні)", counts.Waitlist)
+	}
+	return text
+}
+
+// formatEventCard renders the full event as users see it on its detail card.
+func formatEventCard(event *internalModels.Event) string {
+	text := fmt.Sprintf("<b>%s</b>\n\n", html.EscapeString(event.Title)) +
+		fmt.Sprintf("📅 %s\n", messages.FormatEventDate(event))
+
+	if event.Location != nil && *event.Location != "" {
+		text += fmt.Sprintf("📍 %s\n", html.EscapeString(*event.Location))
+	}
+	if event.Category != nil && *event.Category != "" {
+		text += fmt.Sprintf("🏷 %s\n", html.EscapeString(*event.Category))
+	}
+
+	text += fmt.Sprintf("\n%s\n", html.EscapeString(event.Description))
+
+	return text
+}
+
+// eventShareURL returns a t.me share link that opens the event card in the
+// bot, or an empty string when the bot username is unknown.
+func eventShareURL(ctx context.Context, b *bot.Bot, event *internalModels.Event) string {
+	username := getBotUsername(ctx, b)
+	if username == "" {
+		return ""
+	}
+
+	link := fmt.Sprintf("https://t.me/%s?start=%s%d", username, eventDeepLinkPrefix, event.ID)
+	text := fmt.Sprintf("%s — %s", event.Title, messages.FormatEventDate(event))
+
+	return "https://t.me/share/url?url=" + url.QueryEscape(link) + "&text=" + url.QueryEscape(text)
+}
+
+// sendEventDeepLink answers "/start event_<id>" with the event card.
+func sendEventDeepLink(ctx context.Context, b *bot.Bot, userID int64, chatID int64, payload string) {
+	eventID, ok := strings.CutPrefix(payload, eventDeepLinkPrefix)
+	if !ok {
+		return
+	}
+
+	text, keyboard, poster := getEventCard(ctx, b, userID, eventID)
+	sendEventCard(ctx, b, chatID, text, keyboard, poster)
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+func TestFormatEventCardEscapes(t *testing.T) {
+	location := "<Youth> hall"
+	category := "Q&A"
+	event := &internalModels.Event{
+		Title:       "Q&A <Youth>",
+		Date:        time.Date(2026, time.November, 1, 17, 0, 0, 0, time.UTC),
+		Description: "1 < 2 & 3 > 2",
+		Location:    &location,
+		Category:    &category,
+	}
+
+	card := "<b>Q&amp;A &lt;Youth&gt;</b>\n\n" +
+		"📅 01.11.2026 18:00\n" +
+		"📍 &lt;Youth&gt; hall\n" +
+		"🏷 Q&amp;A\n" +
+		"\n1 &lt; 2 &amp; 3 &gt; 2\n"
+	if got := formatEventCard(event); got != card {
+		t.Errorf("formatEventCard() =\n%s\nwant:\n%s", got, card)
+	}
+
+	entry := "<b>3. Q&amp;A &lt;Youth&gt;</b>\n" +
+		"📅 01.11.2026 18:00\n" +
+		"📍 &lt;Youth&gt; hall\n"
+	if got := formatEventListEntry(3, event); got != entry {
+		t.Errorf("formatEventListEntry() =\n%s\nwant:\n%s", got, entry)
+	}
+}
@@ -2,12 +2,13 @@ package handlers
 
 import (
 	"context"
-	"fmt"
 	"log"
-	"time"
+	"strconv"
+	"strings"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/conversation"
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
@@ -18,14 +19,18 @@ import (
 
 var eventRepo = repository.NewEventRepository()
 var userRepo = repository.NewUserRepository()
+var recurringEventRepo = repository.NewRecurringEventRepository()
+var recurringExceptionRepo = repository.NewRecurringExceptionRepository()
+var eventReminderRepo = repository.NewEventReminderRepository()
+var eventRegistrationRepo = repository.NewEventRegistrationRepository()
+var categoryRepo = repository.NewCategoryRepository()
 
 func StartHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
 	userID := update.Message.From.ID
 	username := update.Message.From.Username
 	firstName := update.Message.From.FirstName
 
-	location, _ := time.LoadLocation("Europe/Warsaw")
-	now := time.Now().In(location)
+	now := clock.Now()
 
 	user := &internalModels.User{
 		UserID:       userID,
@@ -61,6 +66,11 @@ func StartHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
 			IsDisabled: bot.True(),
 		},
 	})
+
+	// Shared event links open the bot with "/start event_<id>".
+	if _, payload, ok := strings.Cut(update.Message.Text, " "); ok {
+		sendEventDeepLink(ctx, b, update.Message.From.ID, update.Message.Chat.ID, strings.TrimSpace(payload))
+	}
 }
 
 func HelpHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
@@ -117,6 +127,21 @@ func CallbackHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
 	callback := update.CallbackQuery
 	data := callback.Data
 
+	if strings.HasPrefix(data, "rsvp") {
+		RSVPCallbackHandler(ctx, b, callback)
+		return
+	}
+
+	if strings.HasPrefix(data, "ics:") {
+		EventICSCallbackHandler(ctx, b, callback)
+		return
+	}
+
+	if strings.HasPrefix(data, "map:") {
+		EventMapCallbackHandler(ctx, b, callback)
+		return
+	}
+
 	var text string
 	var keyboard *models.InlineKeyboardMarkup
 
@@ -181,24 +206,47 @@ func CallbackHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
 		text = messages.GetText("contact")
 		keyboard = keyboards.BackToMainMenuKeyboard()
 	case "events":
-		text = getEventsListText(ctx)
-		keyboard = keyboards.BackToMainMenuKeyboard()
+		text, keyboard = getEventsPage(ctx, 0, 0)
+
+	case "events_categories":
+		text, keyboard = getEventsCategoryFilter(ctx)
 
 	default:
+		if strings.HasPrefix(data, "calendar_") {
+			text, keyboard = getCalendarView(ctx, data)
+			break
+		}
+
+		if param, ok := strings.CutPrefix(data, "events_page:"); ok {
+			page, category, _ := strings.Cut(param, ":")
+			number, _ := strconv.Atoi(page)
+			categoryID, _ := strconv.Atoi(category)
+			text, keyboard = getEventsPage(ctx, number, categoryID)
+			break
+		}
+
+		if month, ok := strings.CutPrefix(data, "past_events"); ok {
+			text, keyboard = getPastEvents(ctx, strings.TrimPrefix(month, ":"))
+			break
+		}
+
+		if eventID, ok := strings.CutPrefix(data, "event:"); ok {
+			text, keyboard, poster := getEventCard(ctx, b, callback.From.ID, eventID)
+			updateEventCard(ctx, b, callback.Message.Message, text, keyboard, poster)
+
+			b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+			})
+			return
+		}
+
 		text = messages.GetText("other_answer")
 		keyboard = keyboards.BackToMainMenuKeyboard()
 	}
 
-	b.EditMessageText(ctx, &bot.EditMessageTextParams{
-		ChatID:      callback.Message.Message.Chat.ID,
-		MessageID:   callback.Message.Message.ID,
-		Text:        text,
-		ParseMode:   models.ParseModeHTML,
-		ReplyMarkup: keyboard,
-		LinkPreviewOptions: &models.LinkPreviewOptions{
-			IsDisabled: bot.True(),
-		},
-	})
+	// Going back from an event card with a poster: a photo message cannot
+	// be edited into text, so updateEventCard replaces it.
+	updateEventCard(ctx, b, callback.Message.Message, text, keyboard, "")
 
 	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 		CallbackQueryID: callback.ID,
@@ -222,11 +270,12 @@ func DefaultHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
 			return
 		}
 
-		if state != "" &&
-			state != internalModels.StateAwaitingDeleteID &&
-			state != internalModels.StateAwaitingDeleteConfirm &&
-			state != internalModels.StateAwaitingBroadcastText &&
-			state != internalModels.StateAwaitingBroadcastConfirm {
+		if isRecurringDialogState(state) && middleware.IsAdmin(userID) {
+			HandleRecurringEventDialogMessage(ctx, b, update)
+			return
+		}
+
+		if isEventDialogState(state) {
 			HandleEventDialogMessage(ctx, b, update)
 			return
 		}
@@ -236,6 +285,38 @@ func DefaultHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
 			return
 		}
 
+		if isEventEditState(state) && middleware.IsAdmin(userID) {
+			HandleEventEditMessage(ctx, b, update)
+			return
+		}
+
+		if isEventCloneState(state) && middleware.IsAdmin(userID) {
+			HandleEventCloneMessage(ctx, b, update)
+			return
+		}
+
+		if state == internalModels.StateAwaitingPublishAt && middleware.IsAdmin(userID) {
+			HandlePublishAtMessage(ctx, b, update)
+			return
+		}
+
+		if (state == internalModels.StateAwaitingImportFile ||
+			state == internalModels.StateAwaitingImportConfirm) &&
+			middleware.IsAdmin(userID) {
+			HandleEventImportMessage(ctx, b, update)
+			return
+		}
+
+		if isCategoryState(state) && middleware.IsAdmin(userID) {
+			HandleCategoryMessage(ctx, b, update)
+			return
+		}
+
+		if state == internalModels.StateAwaitingReminderEventID && middleware.IsAdmin(userID) {
+			HandleReminderEventIDMessage(ctx, b, update)
+			return
+		}
+
 		if (state == internalModels.StateAwaitingBroadcastText ||
 			state == internalModels.StateAwaitingBroadcastConfirm) &&
 			middleware.IsAdmin(userID) {
@@ -260,8 +341,7 @@ func DefaultHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
 			keyboard = keyboards.BackToMainMenuKeyboard()
 
 		case "📅 Події":
-			text = getEventsListText(ctx)
-			keyboard = keyboards.BackToMainMenuKeyboard()
+			text, keyboard = getCalendarView(ctx, "calendar_week:0")
 
 		case "💳 Підтримати":
 			text = messages.GetText("donation")
@@ -317,55 +397,6 @@ func DefaultHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
 
 }
 
-func getEventsListText(ctx context.Context) string {
-	events, err := eventRepo.GetUpcoming(ctx)
-	if err != nil {
-		log.Printf("Error getting upcoming events: %v", err)
-		return messages.GetText("no_events")
-	}
-
-	if len(events) == 0 {
-		return messages.GetText("no_events")
-	}
-
-	text := "📅 <b>Найближчі події</b>\n\n"
-
-	for i, event := range events {
-		text += fmt.Sprintf(
-			"<b>%d. %s</b>\n"+
-				"📅 %s\n"+
-				"📝 %s\n",
-			i+1,
-			event.Title,
-			formatEventDate(event.Date),
-			event.Description,
-		)
-
-		if event.Location != nil && *event.Location != "" {
-			text += fmt.Sprintf("📍 %s\n", *event.Location)
-		}
-
-		if event.Category != nil && *event.Category != "" {
-			text += fmt.Sprintf("🏷 %s\n", *event.Category)
-		}
-
-		if event.RegistrationURL != nil && *event.RegistrationURL != "" {
-			text += fmt.Sprintf("🔗 <a href=\"%s\">Реєстрація</a>\n", *event.RegistrationURL)
-		}
-
-		text += "\n"
-	}
-
-	return text
-}
-
-func formatEventDate(t time.Time) string {
-	if t.Hour() == 0 && t.Minute() == 0 {
-		return t.Format("02.01.2006")
-	}
-	return t.Format("02.01.2006 15:04")
-}
-
 func handleUnsubscribe(ctx context.Context, b *bot.Bot, update *models.Update) {
 	userID := update.Message.From.ID
 
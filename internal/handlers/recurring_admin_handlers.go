@@ -0,0 +1,521 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/conversation"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/recurrence"
+)
+
+// RecurringCallbackHandler handles every "admin_recurring*" callback. Callbacks
+// that carry a parameter use the "action:param" format, where param starts with
+// the series ID.
+func RecurringCallbackHandler(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery) {
+	action, param, _ := strings.Cut(callback.Data, ":")
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+
+	switch action {
+	case "admin_recurring":
+		text, keyboard = getAdminRecurringList(ctx)
+
+	case "admin_recurring_add":
+		StartAddRecurringEventDialog(ctx, b, callback.From.ID, callback.Message.Message.Chat.ID)
+
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+		})
+		return
+
+	case "admin_recurring_day":
+		handleRecurringDayCallback(ctx, b, callback, param)
+		return
+
+	case "admin_recurring_offset":
+		handleRecurringOffsetCallback(ctx, b, callback, param)
+		return
+
+	case "admin_recurring_view":
+		text, keyboard = getAdminRecurringEvent(ctx, param)
+
+	case "admin_recurring_pause", "admin_recurring_resume":
+		isActive := action == "admin_recurring_resume"
+		eventID, err := strconv.Atoi(param)
+		if err == nil {
+			err = recurringEventRepo.SetActive(ctx, eventID, isActive)
+		}
+		if err != nil {
+			log.Printf("Error changing recurring event status: %v", err)
+			b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            "❌ Помилка зміни статусу",
+				ShowAlert:       true,
+			})
+			return
+		}
+		text, keyboard = getAdminRecurringEvent(ctx, param)
+
+	case "admin_recurring_holiday_skip", "admin_recurring_holiday_run":
+		policy := internalModels.HolidayPolicyRun
+		if action == "admin_recurring_holiday_skip" {
+			policy = internalModels.HolidayPolicySkip
+		}
+		eventID, err := strconv.Atoi(param)
+		if err == nil {
+			err = recurringEventRepo.SetHolidayPolicy(ctx, eventID, policy)
+		}
+		if err != nil {
+			log.Printf("Error changing recurring event holiday policy: %v", err)
+			b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+				Text:            "❌ Помилка зміни налаштування",
+				ShowAlert:       true,
+			})
+			return
+		}
+		text, keyboard = getAdminRecurringEvent(ctx, param)
+
+	case "admin_recurring_holidays":
+		text, keyboard = getAdminRecurringHolidays(ctx)
+
+	case "admin_recurring_dates":
+		text, keyboard = getAdminRecurringDates(ctx, param)
+
+	case "admin_recurring_occ":
+		text, keyboard = getAdminRecurringOccurrence(ctx, param)
+
+	case "admin_recurring_occ_cancel", "admin_recurring_occ_reset",
+		"admin_recurring_occ_time", "admin_recurring_occ_place", "admin_recurring_occ_date":
+		text, keyboard = handleRecurringOccurrenceAction(ctx, b, callback, action, param)
+		if text == "" {
+			b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: callback.ID,
+			})
+			return
+		}
+
+	case "admin_recurring_edit":
+		event, err := getRecurringEventByParam(ctx, param)
+		if err != nil {
+			text = "❌ Регулярну подію не знайдено."
+			keyboard = keyboards.AdminRecurringKeyboard()
+			break
+		}
+		text = fmt.Sprintf("✏️ <b>Редагування: %s</b>\n\nОберіть поле, яке потрібно змінити:", html.EscapeString(event.Title))
+		keyboard = keyboards.RecurringEditFieldsKeyboard(event)
+
+	case "admin_recurring_edit_field":
+		handleRecurringEditField(ctx, b, callback, param)
+		return
+
+	case "admin_recurring_edit_day", "admin_recurring_edit_offset":
+		handleRecurringEditChoice(ctx, b, callback, action, param)
+		return
+
+	case "admin_recurring_delete":
+		event, err := getRecurringEventByParam(ctx, param)
+		if err != nil {
+			text = "❌ Регулярну подію не знайдено."
+			keyboard = keyboards.AdminRecurringKeyboard()
+			break
+		}
+		text = fmt.Sprintf(
+			"🗑️ <b>Підтвердження видалення</b>\n\n"+
+				"Ви дійсно хочете видалити регулярну подію?\n\n"+
+				"<b>%s</b>\n"+
+				"📅 %s\n"+
+				"ID: %d\n\n"+
+				"💡 Якщо подія лише тимчасово не проводиться, краще її призупинити.",
+			html.EscapeString(event.Title),
+			messages.FormatSchedule(event),
+			event.ID,
+		)
+		keyboard = keyboards.RecurringDeleteConfirmKeyboard(event.ID)
+
+	case "admin_recurring_delete_confirm":
+		eventID, err := strconv.Atoi(param)
+		if err == nil {
+			err = recurringEventRepo.Delete(ctx, eventID)
+		}
+		if err != nil {
+			log.Printf("Error deleting recurring event: %v", err)
+			text = "❌ Помилка видалення регулярної події."
+			keyboard = keyboards.AdminRecurringKeyboard()
+			break
+		}
+		text = fmt.Sprintf("✅ Регулярну подію (ID: %d) успішно видалено!", eventID)
+		keyboard = keyboards.AdminRecurringKeyboard()
+
+	default:
+		log.Printf("RecurringCallbackHandler: unknown command '%s'", callback.Data)
+		text = "Невідома команда"
+		keyboard = keyboards.AdminRecurringKeyboard()
+	}
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      callback.Message.Message.Chat.ID,
+		MessageID:   callback.Message.Message.ID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+}
+
+func getRecurringEventByParam(ctx context.Context, param string) (*internalModels.RecurringEvent, error) {
+	idStr, _, _ := strings.Cut(param, ":")
+	eventID, err := strconv.Atoi(idStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurring event id %q: %w", idStr, err)
+	}
+	return recurringEventRepo.GetByID(ctx, eventID)
+}
+
+func getAdminRecurringList(ctx context.Context) (string, *models.InlineKeyboardMarkup) {
+	events, err := recurringEventRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Error getting recurring events: %v", err)
+		return "❌ Помилка отримання регулярних подій з бази даних", keyboards.BackToAdminPanelKeyboard()
+	}
+
+	text := "🔁 <b>Регулярні події</b>\n\n"
+
+	if len(events) == 0 {
+		text += "Регулярних подій поки що немає."
+		return text, keyboards.AdminRecurringListKeyboard(events)
+	}
+
+	for i, event := range events {
+		status := "✅"
+		if !event.IsActive {
+			status = "⏸"
+		}
+
+		text += fmt.Sprintf(
+			"%s <b>%d. %s</b>\n"+
+				"📅 %s\n"+
+				"🔔 %s\n"+
+				"ID: %d\n\n",
+			status,
+			i+1,
+			html.EscapeString(event.Title),
+			messages.FormatSchedule(&event),
+			formatRecurringReminderTime(&event),
+			event.ID,
+		)
+	}
+
+	text += "💡 ✅ - активна, ⏸ - призупинена\nОберіть подію для керування:"
+
+	return text, keyboards.AdminRecurringListKeyboard(events)
+}
+
+func getAdminRecurringEvent(ctx context.Context, param string) (string, *models.InlineKeyboardMarkup) {
+	event, err := getRecurringEventByParam(ctx, param)
+	if err != nil {
+		log.Printf("Error getting recurring event: %v", err)
+		return "❌ Регулярну подію не знайдено.", keyboards.AdminRecurringKeyboard()
+	}
+
+	return formatAdminRecurringEvent(event), keyboards.AdminRecurringEventKeyboard(event)
+}
+
+func formatAdminRecurringEvent(event *internalModels.RecurringEvent) string {
+	status := "✅ активна"
+	if !event.IsActive {
+		status = "⏸ призупинена (нагадування не надсилаються)"
+	}
+
+	holidayPolicy := "проводиться"
+	if event.SkipsHolidays() {
+		holidayPolicy = "пропускається"
+	}
+
+	return "🔁 <b>Регулярна подія</b>\n\n" +
+		formatRecurringEventDetails(event) +
+		fmt.Sprintf("\nУ державні свята: %s", holidayPolicy) +
+		fmt.Sprintf("\nСтатус: %s\nID: %d", status, event.ID)
+}
+
+func handleRecurringEditField(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, param string) {
+	userID := callback.From.ID
+	chatID := callback.Message.Message.Chat.ID
+
+	_, field, _ := strings.Cut(param, ":")
+
+	event, err := getRecurringEventByParam(ctx, param)
+	if err != nil {
+		log.Printf("Error getting recurring event for edit: %v", err)
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            "❌ Регулярну подію не знайдено",
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	// The day of an RRULE series comes from its rule; a stale keyboard may
+	// still offer it.
+	if field == "day" && event.RRule != "" {
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            "❌ День задає правило повторення. Змініть поле «Повторення».",
+			ShowAlert:       true,
+		})
+		return
+	}
+
+	conv := conversation.GetManager()
+	conv.ClearState(userID)
+	conv.SetState(userID, internalModels.StateAwaitingRecurringEditValue)
+
+	conversation := conv.GetConversation(userID)
+	conversation.RecurringEventData = event
+	conversation.EditField = field
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+
+	switch field {
+	case "title":
+		text = fmt.Sprintf("Поточна назва: <b>%s</b>\n\nВведіть нову назву:", html.EscapeString(event.Title))
+	case "description":
+		text = fmt.Sprintf("Поточний опис:\n%s\n\nВведіть новий опис:", html.EscapeString(event.Description))
+	case "day":
+		text = fmt.Sprintf("Поточний день: <b>%s</b>\n\nОберіть новий день тижня:", event.GetDayName())
+		keyboard = keyboards.RecurringWeekdayKeyboard("admin_recurring_edit_day")
+	case "time":
+		text = fmt.Sprintf("Поточний час: <b>%s</b>\n\nВведіть новий час у форматі <code>ГГ:ХХ</code>:", event.EventTime)
+	case "reminder_offset":
+		text = fmt.Sprintf("Зараз нагадування: <b>%s</b>\n\nОберіть кнопкою або введіть, за скільки днів до події (0–6):", messages.FormatReminderOffset(-event.ReminderDayOffset*24*60))
+		keyboard = keyboards.RecurringReminderOffsetKeyboard("admin_recurring_edit_offset")
+	case "reminder_time":
+		text = fmt.Sprintf("Поточний час нагадування: <b>%s</b>\n\nВведіть новий час у форматі <code>ГГ:ХХ</code>:", event.ReminderTime)
+	case "location":
+		text = fmt.Sprintf("Поточне місце: <b>%s</b>\n\nВведіть нове місце або /skip щоб очистити:", html.EscapeString(event.Location))
+	case "category":
+		text = fmt.Sprintf("Поточна категорія: <b>%s</b>\n\nВведіть нову категорію або /skip щоб очистити:", html.EscapeString(event.Category))
+	case "registration_url":
+		text = fmt.Sprintf("Поточне посилання: %s\n\nВведіть нове посилання або /skip щоб очистити:", html.EscapeString(event.RegistrationURL))
+	case "rrule":
+		current := "щотижня (за днем тижня)"
+		if event.RRule != "" {
+			current = "<code>" + event.RRule + "</code>"
+		}
+		text = fmt.Sprintf("Поточне правило: %s\n\n", current) +
+			"Введіть правило повторення у форматі RRULE:\n" +
+			"• <code>FREQ=WEEKLY;INTERVAL=2;BYDAY=FR</code> — кожна друга п'ятниця\n" +
+			"• <code>FREQ=MONTHLY;BYDAY=1SA</code> — перша субота місяця\n" +
+			"• <code>FREQ=MONTHLY;BYDAY=-1SU</code> — остання неділя місяця\n" +
+			"• <code>FREQ=MONTHLY;BYMONTHDAY=15</code> — 15 числа щомісяця\n\n" +
+			"Можна додати <code>;UNTIL=20261231</code> або <code>;COUNT=10</code>.\n" +
+			"Надішліть /skip, щоб повернутися до щотижневого повторення."
+	case "start_date":
+		current := "дата створення"
+		if event.StartDate != "" {
+			current = formatStartDate(event.StartDate)
+		}
+		text = fmt.Sprintf("Поточна дата початку: <b>%s</b>\n\n", current) +
+			"Від неї рахуються правила з інтервалом (наприклад, кожна друга п'ятниця) та COUNT.\n\n" +
+			"Введіть дату у форматі <code>ДД.ММ.РРРР</code> або /skip щоб очистити:"
+	default:
+		conv.ClearState(userID)
+		return
+	}
+
+	text += "\n\nДля скасування натисніть /cancel"
+
+	params := &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	}
+	if keyboard != nil {
+		params.ReplyMarkup = keyboard
+	}
+
+	b.SendMessage(ctx, params)
+}
+
+func handleRecurringEditChoice(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, action string, param string) {
+	userID := callback.From.ID
+	chatID := callback.Message.Message.Chat.ID
+
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+
+	expectedField := "day"
+	minValue, maxValue := 0, 6
+	if action == "admin_recurring_edit_offset" {
+		expectedField = "reminder_offset"
+		minValue, maxValue = -6, 0
+	}
+
+	value, err := strconv.Atoi(param)
+	if err != nil || value < minValue || value > maxValue || conversation == nil ||
+		conversation.State != internalModels.StateAwaitingRecurringEditValue ||
+		conversation.EditField != expectedField {
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            "❌ Цей крок вже неактуальний",
+		})
+		return
+	}
+
+	if expectedField == "day" {
+		conversation.RecurringEventData.DayOfWeek = value
+	} else {
+		conversation.RecurringEventData.ReminderDayOffset = value
+	}
+
+	b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:    chatID,
+		MessageID: callback.Message.Message.ID,
+	})
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	saveRecurringEdit(ctx, b, userID, chatID)
+}
+
+func handleRecurringEditValue(ctx context.Context, b *bot.Bot, userID int64, chatID int64, value string) {
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+	event := conversation.RecurringEventData
+
+	optional := func() string {
+		if value == "/skip" {
+			return ""
+		}
+		return value
+	}
+
+	switch conversation.EditField {
+	case "title":
+		event.Title = value
+	case "description":
+		event.Description = value
+	case "day":
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        "👆 Оберіть день тижня кнопкою:",
+			ReplyMarkup: keyboards.RecurringWeekdayKeyboard("admin_recurring_edit_day"),
+		})
+		return
+	case "time", "reminder_time":
+		normalized, ok := normalizeClockTime(value)
+		if !ok {
+			sendInvalidClockTime(ctx, b, chatID)
+			return
+		}
+		if conversation.EditField == "time" {
+			event.EventTime = normalized
+		} else {
+			event.ReminderTime = normalized
+		}
+	case "reminder_offset":
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 0 || days > 6 {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID:      chatID,
+				Text:        "❌ Введіть кількість днів від 0 до 6 або оберіть кнопкою:",
+				ReplyMarkup: keyboards.RecurringReminderOffsetKeyboard("admin_recurring_edit_offset"),
+			})
+			return
+		}
+		event.ReminderDayOffset = -days
+	case "location":
+		event.Location = optional()
+	case "category":
+		event.Category = optional()
+	case "registration_url":
+		event.RegistrationURL = optional()
+	case "rrule":
+		if value == "/skip" {
+			event.SetRule(nil)
+			break
+		}
+		rule, err := recurrence.Parse(value)
+		if err != nil {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID:    chatID,
+				Text:      fmt.Sprintf("❌ Неправильне правило: %s\n\nСпробуйте ще раз:", err),
+				ParseMode: models.ParseModeHTML,
+			})
+			return
+		}
+		event.SetRule(rule)
+	case "start_date":
+		if value == "/skip" {
+			event.StartDate = ""
+			break
+		}
+		startDate, err := time.Parse("02.01.2006", value)
+		if err != nil {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID:    chatID,
+				Text:      "❌ Неправильний формат дати! Використовуйте <code>ДД.ММ.РРРР</code>:",
+				ParseMode: models.ParseModeHTML,
+			})
+			return
+		}
+		event.StartDate = startDate.Format(internalModels.StartDateLayout)
+	}
+
+	saveRecurringEdit(ctx, b, userID, chatID)
+}
+
+func saveRecurringEdit(ctx context.Context, b *bot.Bot, userID int64, chatID int64) {
+	conv := conversation.GetManager()
+	event := conv.GetConversation(userID).RecurringEventData
+
+	conv.ClearState(userID)
+
+	err := recurringEventRepo.Update(ctx, event)
+	if err != nil {
+		log.Printf("Error updating recurring event: %v", err)
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        "❌ Помилка збереження змін.",
+			ReplyMarkup: keyboards.AdminRecurringKeyboard(),
+		})
+		return
+	}
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        "✅ Зміни збережено!\n\n" + formatAdminRecurringEvent(event),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboards.AdminRecurringEventKeyboard(event),
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+}
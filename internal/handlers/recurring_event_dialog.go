@@ -0,0 +1,430 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/conversation"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+func isRecurringDialogState(state string) bool {
+	switch state {
+	case internalModels.StateAwaitingRecurringTitle,
+		internalModels.StateAwaitingRecurringDesc,
+		internalModels.StateAwaitingRecurringDay,
+		internalModels.StateAwaitingRecurringTime,
+		internalModels.StateAwaitingRecurringReminderOffset,
+		internalModels.StateAwaitingRecurringReminderTime,
+		internalModels.StateAwaitingRecurringLocation,
+		internalModels.StateAwaitingRecurringCategory,
+		internalModels.StateAwaitingRecurringRegURL,
+		internalModels.StateAwaitingRecurringEditValue,
+		internalModels.StateAwaitingRecurringExceptionValue:
+		return true
+	}
+	return false
+}
+
+func StartAddRecurringEventDialog(ctx context.Context, b *bot.Bot, userID int64, chatID int64) {
+	conv := conversation.GetManager()
+	conv.ClearState(userID)
+	conv.SetState(userID, internalModels.StateAwaitingRecurringTitle)
+
+	text := "🔁 <b>Додавання регулярної події</b>\n\n" +
+		"Крок 1 з 9\n" +
+		"Введіть <b>назву події</b>:\n\n" +
+		"Наприклад: Недільне богослужіння\n\n" +
+		"Для скасування натисніть /cancel"
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+func HandleRecurringEventDialogMessage(ctx context.Context, b *bot.Bot, update *models.Update) {
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+	text := strings.TrimSpace(update.Message.Text)
+
+	conv := conversation.GetManager()
+	state := conv.GetState(userID)
+
+	switch state {
+	case internalModels.StateAwaitingRecurringTitle:
+		handleRecurringTitle(ctx, b, userID, chatID, text)
+	case internalModels.StateAwaitingRecurringDesc:
+		handleRecurringDescription(ctx, b, userID, chatID, text)
+	case internalModels.StateAwaitingRecurringDay:
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        "👆 Оберіть день тижня кнопкою:",
+			ReplyMarkup: keyboards.RecurringWeekdayKeyboard("admin_recurring_day"),
+		})
+	case internalModels.StateAwaitingRecurringTime:
+		handleRecurringTime(ctx, b, userID, chatID, text)
+	case internalModels.StateAwaitingRecurringReminderOffset:
+		days, err := strconv.Atoi(text)
+		if err != nil || days < 0 || days > 6 {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID:      chatID,
+				Text:        "❌ Введіть кількість днів від 0 до 6 або оберіть кнопкою:",
+				ReplyMarkup: keyboards.RecurringReminderOffsetKeyboard("admin_recurring_offset"),
+			})
+			return
+		}
+		setRecurringReminderOffset(ctx, b, userID, chatID, -days)
+	case internalModels.StateAwaitingRecurringReminderTime:
+		handleRecurringReminderTime(ctx, b, userID, chatID, text)
+	case internalModels.StateAwaitingRecurringLocation:
+		handleRecurringLocation(ctx, b, userID, chatID, text)
+	case internalModels.StateAwaitingRecurringCategory:
+		handleRecurringCategory(ctx, b, userID, chatID, text)
+	case internalModels.StateAwaitingRecurringRegURL:
+		handleRecurringRegistrationURL(ctx, b, userID, chatID, text)
+	case internalModels.StateAwaitingRecurringEditValue:
+		handleRecurringEditValue(ctx, b, userID, chatID, text)
+	case internalModels.StateAwaitingRecurringExceptionValue:
+		HandleRecurringExceptionMessage(ctx, b, update)
+	}
+}
+
+func handleRecurringTitle(ctx context.Context, b *bot.Bot, userID int64, chatID int64, title string) {
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+	conversation.RecurringEventData.Title = title
+
+	conv.SetState(userID, internalModels.StateAwaitingRecurringDesc)
+
+	text := "✅ Назва збережена!\n\n" +
+		"Крок 2 з 9\n" +
+		"Введіть <b>опис події</b>:\n\n" +
+		"Для скасування натисніть /cancel"
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+func handleRecurringDescription(ctx context.Context, b *bot.Bot, userID int64, chatID int64, description string) {
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+	conversation.RecurringEventData.Description = description
+
+	conv.SetState(userID, internalModels.StateAwaitingRecurringDay)
+
+	text := "✅ Опис збережено!\n\n" +
+		"Крок 3 з 9\n" +
+		"Оберіть <b>день тижня</b>, коли проходить подія:\n\n" +
+		"Для скасування натисніть /cancel"
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboards.RecurringWeekdayKeyboard("admin_recurring_day"),
+	})
+}
+
+func handleRecurringDayCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, param string) {
+	userID := callback.From.ID
+	chatID := callback.Message.Message.Chat.ID
+
+	conv := conversation.GetManager()
+	day, err := strconv.Atoi(param)
+	if err != nil || day < 0 || day > 6 || conv.GetState(userID) != internalModels.StateAwaitingRecurringDay {
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            "❌ Цей крок вже неактуальний",
+		})
+		return
+	}
+
+	conversation := conv.GetConversation(userID)
+	conversation.RecurringEventData.DayOfWeek = day
+
+	conv.SetState(userID, internalModels.StateAwaitingRecurringTime)
+
+	b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: callback.Message.Message.ID,
+		Text:      fmt.Sprintf("✅ День тижня: <b>%s</b>", internalModels.WeekdayName(day)),
+		ParseMode: models.ParseModeHTML,
+	})
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	text := "Крок 4 з 9\n" +
+		"Введіть <b>час початку події</b>:\n\n" +
+		"Формат: <code>ГГ:ХХ</code>, наприклад <code>16:00</code>\n\n" +
+		"Для скасування натисніть /cancel"
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+func handleRecurringTime(ctx context.Context, b *bot.Bot, userID int64, chatID int64, value string) {
+	eventTime, ok := normalizeClockTime(value)
+	if !ok {
+		sendInvalidClockTime(ctx, b, chatID)
+		return
+	}
+
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+	conversation.RecurringEventData.EventTime = eventTime
+
+	conv.SetState(userID, internalModels.StateAwaitingRecurringReminderOffset)
+
+	text := "✅ Час збережено!\n\n" +
+		"Крок 5 з 9\n" +
+		"<b>Коли надсилати нагадування?</b>\n\n" +
+		"Оберіть кнопкою або введіть, за скільки днів до події (0–6).\n\n" +
+		"Для скасування натисніть /cancel"
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboards.RecurringReminderOffsetKeyboard("admin_recurring_offset"),
+	})
+}
+
+func handleRecurringOffsetCallback(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, param string) {
+	userID := callback.From.ID
+	chatID := callback.Message.Message.Chat.ID
+
+	conv := conversation.GetManager()
+	offset, err := strconv.Atoi(param)
+	if err != nil || offset > 0 || offset < -6 || conv.GetState(userID) != internalModels.StateAwaitingRecurringReminderOffset {
+		b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            "❌ Цей крок вже неактуальний",
+		})
+		return
+	}
+
+	b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:    chatID,
+		MessageID: callback.Message.Message.ID,
+	})
+
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: callback.ID,
+	})
+
+	setRecurringReminderOffset(ctx, b, userID, chatID, offset)
+}
+
+func setRecurringReminderOffset(ctx context.Context, b *bot.Bot, userID int64, chatID int64, offset int) {
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+	conversation.RecurringEventData.ReminderDayOffset = offset
+
+	conv.SetState(userID, internalModels.StateAwaitingRecurringReminderTime)
+
+	text := fmt.Sprintf("✅ Нагадування: <b>%s</b>\n\n", messages.FormatReminderOffset(-offset*24*60)) +
+		"Крок 6 з 9\n" +
+		"Введіть <b>час нагадування</b>:\n\n" +
+		"Формат: <code>ГГ:ХХ</code>, наприклад <code>18:00</code>\n\n" +
+		"Для скасування натисніть /cancel"
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+func handleRecurringReminderTime(ctx context.Context, b *bot.Bot, userID int64, chatID int64, value string) {
+	reminderTime, ok := normalizeClockTime(value)
+	if !ok {
+		sendInvalidClockTime(ctx, b, chatID)
+		return
+	}
+
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+	conversation.RecurringEventData.ReminderTime = reminderTime
+
+	conv.SetState(userID, internalModels.StateAwaitingRecurringLocation)
+
+	text := "✅ Час нагадування збережено!\n\n" +
+		"Крок 7 з 9\n" +
+		"Введіть <b>місце проведення</b> (адресу):\n\n" +
+		"Або натисніть /skip щоб пропустити\n" +
+		"Для скасування натисніть /cancel"
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+func handleRecurringLocation(ctx context.Context, b *bot.Bot, userID int64, chatID int64, location string) {
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+
+	if location != "/skip" {
+		conversation.RecurringEventData.Location = location
+	}
+
+	conv.SetState(userID, internalModels.StateAwaitingRecurringCategory)
+
+	text := "✅ Місце збережено!\n\n" +
+		"Крок 8 з 9\n" +
+		"Введіть <b>категорію події</b>:\n\n" +
+		"Наприклад: Богослужіння, Молодіжка, Молитва\n\n" +
+		"Або натисніть /skip щоб пропустити\n" +
+		"Для скасування натисніть /cancel"
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+func handleRecurringCategory(ctx context.Context, b *bot.Bot, userID int64, chatID int64, category string) {
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+
+	if category != "/skip" {
+		conversation.RecurringEventData.Category = category
+	}
+
+	conv.SetState(userID, internalModels.StateAwaitingRecurringRegURL)
+
+	text := "✅ Категорія збережена!\n\n" +
+		"Крок 9 з 9\n" +
+		"Введіть <b>посилання для реєстрації</b>:\n\n" +
+		"Або натисніть /skip щоб пропустити\n" +
+		"Для скасування натисніть /cancel"
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+func handleRecurringRegistrationURL(ctx context.Context, b *bot.Bot, userID int64, chatID int64, url string) {
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+
+	if url != "/skip" {
+		conversation.RecurringEventData.RegistrationURL = url
+	}
+
+	event := conversation.RecurringEventData
+	event.IsActive = true
+	event.HolidayPolicy = internalModels.HolidayPolicyRun
+	event.CreatedAt = time.Now()
+	event.CreatedBy = userID
+
+	err := recurringEventRepo.Create(ctx, event)
+	if err != nil {
+		log.Printf("Error creating recurring event: %v", err)
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   "❌ Помилка збереження регулярної події в базу даних.",
+		})
+		conv.ClearState(userID)
+		return
+	}
+
+	conv.ClearState(userID)
+
+	text := "✅ <b>Регулярну подію успішно створено!</b>\n\n" +
+		formatRecurringEventDetails(event) +
+		fmt.Sprintf("\nID події: %d", event.ID)
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboards.AdminRecurringKeyboard(),
+		LinkPreviewOptions: &models.LinkPreviewOptions{
+			IsDisabled: bot.True(),
+		},
+	})
+}
+
+func normalizeClockTime(value string) (string, bool) {
+	hour, minute, err := internalModels.ParseClockTime(strings.TrimSpace(value))
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute), true
+}
+
+func sendInvalidClockTime(ctx context.Context, b *bot.Bot, chatID int64) {
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text: "❌ Неправильний формат часу!\n\n" +
+			"Використовуйте формат <code>ГГ:ХХ</code>, наприклад <code>16:00</code>\n\n" +
+			"Спробуйте ще раз:",
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+func formatRecurringEventDetails(event *internalModels.RecurringEvent) string {
+	text := fmt.Sprintf("<b>%s</b>\n", html.EscapeString(event.Title)) +
+		fmt.Sprintf("📅 %s\n", messages.FormatSchedule(event)) +
+		fmt.Sprintf("🔔 Нагадування: %s\n", formatRecurringReminderTime(event))
+
+	if event.StartDate != "" {
+		text += fmt.Sprintf("🗓 Починаючи з: %s\n", formatStartDate(event.StartDate))
+	}
+
+	if event.Description != "" {
+		text += fmt.Sprintf("📝 %s\n", html.EscapeString(event.Description))
+	}
+	if event.Location != "" {
+		text += fmt.Sprintf("📍 %s\n", html.EscapeString(event.Location))
+	}
+	if event.Category != "" {
+		text += fmt.Sprintf("🏷 %s\n", html.EscapeString(event.Category))
+	}
+	if event.RegistrationURL != "" {
+		text += fmt.Sprintf("🔗 %s\n", html.EscapeString(event.RegistrationURL))
+	}
+
+	return text
+}
+
+// formatRecurringReminderTime names the reminder weekday only for plain weekly
+// series; for custom rules the weekday can change between occurrences.
+func formatRecurringReminderTime(event *internalModels.RecurringEvent) string {
+	if event.RRule == "" {
+		return fmt.Sprintf("%s о %s (%s)", event.GetReminderDayName(), event.ReminderTime, messages.FormatReminderOffset(-event.ReminderDayOffset*24*60))
+	}
+	return fmt.Sprintf("%s о %s", messages.FormatReminderOffset(-event.ReminderDayOffset*24*60), event.ReminderTime)
+}
+
+func formatStartDate(startDate string) string {
+	t, err := time.Parse(internalModels.StartDateLayout, startDate)
+	if err != nil {
+		return startDate
+	}
+	return t.Format("02.01.2006")
+}
@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/conversation"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+const (
+	exceptionsLookaheadDays = 60
+	exceptionsMaxDates      = 8
+)
+
+// getUpcomingOccurrences returns the resolved occurrences of the series for the
+// next exceptionsLookaheadDays, together with the series' exceptions.
+func getUpcomingOccurrences(ctx context.Context, event *internalModels.RecurringEvent) ([]internalModels.Occurrence, []internalModels.RecurringEventException, error) {
+	exceptions, err := recurringExceptionRepo.GetByEvent(ctx, event.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	location := clock.Location()
+	today := clock.Today()
+
+	occurrences, err := event.ResolvedOccurrences(today, today.AddDate(0, 0, exceptionsLookaheadDays), location, exceptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return occurrences, exceptions, nil
+}
+
+func getAdminRecurringDates(ctx context.Context, param string) (string, *models.InlineKeyboardMarkup) {
+	event, err := getRecurringEventByParam(ctx, param)
+	if err != nil {
+		log.Printf("Error getting recurring event: %v", err)
+		return "❌ Регулярну подію не знайдено.", keyboards.AdminRecurringKeyboard()
+	}
+
+	occurrences, _, err := getUpcomingOccurrences(ctx, event)
+	if err != nil {
+		log.Printf("Error getting occurrences of recurring event %d: %v", event.ID, err)
+		return "❌ Помилка розрахунку дат.", keyboards.AdminRecurringEventKeyboard(event)
+	}
+
+	if len(occurrences) > exceptionsMaxDates {
+		occurrences = occurrences[:exceptionsMaxDates]
+	}
+
+	text := fmt.Sprintf("📆 <b>%s — найближчі дати</b>\n\n", html.EscapeString(event.Title))
+
+	if len(occurrences) == 0 {
+		text += "Найближчим часом подія не запланована."
+	}
+
+	for _, occurrence := range occurrences {
+		text += formatOccurrenceLine(occurrence) + "\n"
+	}
+
+	text += "\nОберіть дату, щоб скасувати її або змінити час чи місце лише для цього разу.\n" +
+		"💡 ❌ - скасовано, 🔀 - змінено, 🇵🇱 - пропущено через свято"
+
+	return text, keyboards.RecurringOccurrencesKeyboard(event.ID, occurrences)
+}
+
+func formatOccurrenceLine(occurrence internalModels.Occurrence) string {
+	weekday := internalModels.WeekdayName(int(occurrence.Start.Weekday()))
+
+	if occurrence.IsCancelled && occurrence.Holiday != "" {
+		return fmt.Sprintf("🇵🇱 <s>%s, %s</s> — свято: %s", weekday, occurrence.Start.Format("02.01.2006"), occurrence.Holiday)
+	}
+	if occurrence.IsCancelled {
+		return fmt.Sprintf("❌ <s>%s, %s</s> — скасовано", weekday, occurrence.Start.Format("02.01.2006"))
+	}
+
+	line := fmt.Sprintf("%s, %s", weekday, occurrence.Start.Format("02.01.2006 о 15:04"))
+	if occurrence.IsChanged {
+		line = "🔀 " + line
+		if occurrence.Location != occurrence.Event.Location && occurrence.Location != "" {
+			line += fmt.Sprintf(" (📍 %s)", html.EscapeString(occurrence.Location))
+		}
+	} else {
+		line = "▫️ " + line
+	}
+
+	return line
+}
+
+// findOccurrence looks up the occurrence scheduled on the given date.
+func findOccurrence(ctx context.Context, event *internalModels.RecurringEvent, scheduledDate string) (*internalModels.Occurrence, *internalModels.RecurringEventException, error) {
+	occurrences, exceptions, err := getUpcomingOccurrences(ctx, event)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var exception *internalModels.RecurringEventException
+	for i := range exceptions {
+		if exceptions[i].OccurrenceDate == scheduledDate {
+			exception = &exceptions[i]
+			break
+		}
+	}
+
+	for i := range occurrences {
+		if occurrences[i].ScheduledDate == scheduledDate {
+			return &occurrences[i], exception, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("recurring event %d has no upcoming occurrence on %s", event.ID, scheduledDate)
+}
+
+func getAdminRecurringOccurrence(ctx context.Context, param string) (string, *models.InlineKeyboardMarkup) {
+	event, err := getRecurringEventByParam(ctx, param)
+	if err != nil {
+		log.Printf("Error getting recurring event: %v", err)
+		return "❌ Регулярну подію не знайдено.", keyboards.AdminRecurringKeyboard()
+	}
+
+	_, scheduledDate, _ := strings.Cut(param, ":")
+
+	occurrence, exception, err := findOccurrence(ctx, event, scheduledDate)
+	if err != nil {
+		log.Printf("Error finding occurrence: %v", err)
+		return "❌ Цю дату не знайдено серед найближчих.", keyboards.AdminRecurringEventKeyboard(event)
+	}
+
+	text := fmt.Sprintf("📆 <b>%s</b>\n\n", html.EscapeString(event.Title)) +
+		fmt.Sprintf("За розкладом: %s\n", formatScheduledDate(occurrence)) +
+		fmt.Sprintf("Зараз: %s\n", formatOccurrenceLine(*occurrence))
+
+	if occurrence.Location != "" && !occurrence.IsCancelled {
+		text += fmt.Sprintf("📍 %s\n", html.EscapeString(occurrence.Location))
+	}
+
+	text += "\nЗміни стосуються лише цієї дати, решта розкладу залишається без змін."
+
+	return text, keyboards.RecurringOccurrenceKeyboard(event.ID, scheduledDate, exception != nil)
+}
+
+func formatScheduledDate(occurrence *internalModels.Occurrence) string {
+	day, err := time.Parse(internalModels.StartDateLayout, occurrence.ScheduledDate)
+	if err != nil {
+		return occurrence.ScheduledDate
+	}
+	return fmt.Sprintf("%s, %s о %s",
+		internalModels.WeekdayName(int(day.Weekday())),
+		day.Format("02.01.2006"),
+		occurrence.Event.EventTime)
+}
+
+// handleRecurringOccurrenceAction handles the per-date actions of the
+// "📆 Окремі дати" screen. param has the form "<id>:<YYYY-MM-DD>".
+func handleRecurringOccurrenceAction(ctx context.Context, b *bot.Bot, callback *models.CallbackQuery, action string, param string) (string, *models.InlineKeyboardMarkup) {
+	userID := callback.From.ID
+	chatID := callback.Message.Message.Chat.ID
+
+	event, err := getRecurringEventByParam(ctx, param)
+	if err != nil {
+		log.Printf("Error getting recurring event: %v", err)
+		return "❌ Регулярну подію не знайдено.", keyboards.AdminRecurringKeyboard()
+	}
+
+	_, scheduledDate, _ := strings.Cut(param, ":")
+
+	_, existing, err := findOccurrence(ctx, event, scheduledDate)
+	if err != nil {
+		log.Printf("Error finding occurrence: %v", err)
+		return "❌ Цю дату не знайдено серед найближчих.", keyboards.AdminRecurringEventKeyboard(event)
+	}
+
+	exception := &internalModels.RecurringEventException{
+		RecurringEventID: event.ID,
+		OccurrenceDate:   scheduledDate,
+	}
+	if existing != nil {
+		exception = existing
+	}
+	exception.CreatedAt = time.Now()
+	exception.CreatedBy = userID
+
+	switch action {
+	case "admin_recurring_occ_cancel":
+		exception.IsCancelled = true
+		if err := recurringExceptionRepo.Save(ctx, exception); err != nil {
+			log.Printf("Error cancelling occurrence: %v", err)
+			return "❌ Помилка збереження.", keyboards.AdminRecurringEventKeyboard(event)
+		}
+
+	case "admin_recurring_occ_reset":
+		if err := recurringExceptionRepo.Delete(ctx, event.ID, scheduledDate); err != nil {
+			log.Printf("Error resetting occurrence: %v", err)
+			return "❌ Помилка збереження.", keyboards.AdminRecurringEventKeyboard(event)
+		}
+
+	case "admin_recurring_occ_time", "admin_recurring_occ_place", "admin_recurring_occ_date":
+		startRecurringExceptionInput(ctx, b, userID, chatID, event, exception, strings.TrimPrefix(action, "admin_recurring_occ_"))
+		return "", nil
+	}
+
+	return getAdminRecurringOccurrence(ctx, param)
+}
+
+func startRecurringExceptionInput(ctx context.Context, b *bot.Bot, userID int64, chatID int64, event *internalModels.RecurringEvent, exception *internalModels.RecurringEventException, field string) {
+	conv := conversation.GetManager()
+	conv.ClearState(userID)
+	conv.SetState(userID, internalModels.StateAwaitingRecurringExceptionValue)
+
+	conversation := conv.GetConversation(userID)
+	conversation.RecurringEventData = event
+	conversation.ExceptionData = exception
+	conversation.EditField = field
+
+	var text string
+	switch field {
+	case "time":
+		text = "🕒 Введіть новий час для цієї дати у форматі <code>ГГ:ХХ</code>:"
+	case "place":
+		text = "📍 Введіть місце проведення для цієї дати:"
+	case "date":
+		text = "📅 Введіть нову дату у форматі <code>ДД.ММ.РРРР</code>:"
+	}
+
+	text += "\n\nДля скасування натисніть /cancel"
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	})
+}
+
+func HandleRecurringExceptionMessage(ctx context.Context, b *bot.Bot, update *models.Update) {
+	userID := update.Message.From.ID
+	chatID := update.Message.Chat.ID
+	value := strings.TrimSpace(update.Message.Text)
+
+	conv := conversation.GetManager()
+	conversation := conv.GetConversation(userID)
+	exception := conversation.ExceptionData
+	event := conversation.RecurringEventData
+
+	switch conversation.EditField {
+	case "time":
+		normalized, ok := normalizeClockTime(value)
+		if !ok {
+			sendInvalidClockTime(ctx, b, chatID)
+			return
+		}
+		exception.OverrideTime = normalized
+	case "place":
+		exception.OverrideLocation = value
+	case "date":
+		day, err := time.Parse("02.01.2006", value)
+		if err != nil {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID:    chatID,
+				Text:      "❌ Неправильний формат дати! Використовуйте <code>ДД.ММ.РРРР</code>:",
+				ParseMode: models.ParseModeHTML,
+			})
+			return
+		}
+		exception.OverrideDate = day.Format(internalModels.StartDateLayout)
+	}
+
+	exception.IsCancelled = false
+
+	conv.ClearState(userID)
+
+	if err := recurringExceptionRepo.Save(ctx, exception); err != nil {
+		log.Printf("Error saving recurring event exception: %v", err)
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        "❌ Помилка збереження змін.",
+			ReplyMarkup: keyboards.AdminRecurringEventKeyboard(event),
+		})
+		return
+	}
+
+	text, keyboard := getAdminRecurringOccurrence(ctx, fmt.Sprintf("%d:%s", event.ID, exception.OccurrenceDate))
+
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        "✅ Зміни збережено!\n\n" + text,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: keyboard,
+	})
+}
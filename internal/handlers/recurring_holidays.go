@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/holidays"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+const holidaysPreviewMonths = 3
+
+// getAdminRecurringHolidays lists the public holidays of the next months
+// together with the active series that fall on them and what happens to
+// each according to its holiday policy.
+func getAdminRecurringHolidays(ctx context.Context) (string, *models.InlineKeyboardMarkup) {
+	location := clock.Location()
+	from := clock.Today()
+	to := from.AddDate(0, holidaysPreviewMonths, 0)
+
+	events, err := recurringEventRepo.GetActive(ctx)
+	if err != nil {
+		log.Printf("Error getting active recurring events: %v", err)
+		return "❌ Помилка отримання регулярних подій з бази даних", keyboards.AdminRecurringKeyboard()
+	}
+
+	exceptions, err := recurringExceptionRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Error getting recurring event exceptions: %v", err)
+		return "❌ Помилка отримання регулярних подій з бази даних", keyboards.AdminRecurringKeyboard()
+	}
+
+	// Occurrences by day, so each holiday can list the series it affects.
+	byDay := make(map[string][]internalModels.Occurrence)
+	for i := range events {
+		occurrences, err := events[i].ResolvedOccurrences(from, to, location, exceptions)
+		if err != nil {
+			log.Printf("Skipping recurring event %d in holidays preview: %v", events[i].ID, err)
+			continue
+		}
+		for _, occurrence := range occurrences {
+			day := occurrence.Start.Format(internalModels.StartDateLayout)
+			if occurrence.Holiday != "" {
+				day = occurrence.ScheduledDate
+			}
+			byDay[day] = append(byDay[day], occurrence)
+		}
+	}
+
+	text := fmt.Sprintf("🇵🇱 <b>Державні свята до %s</b>\n\n", to.Format("02.01.2006"))
+
+	list := holidays.Between(from, to, location)
+	if len(list) == 0 {
+		text += "Найближчим часом державних свят немає."
+	}
+
+	for _, holiday := range list {
+		text += fmt.Sprintf("<b>%s, %s</b> — %s\n",
+			internalModels.WeekdayName(int(holiday.Date.Weekday())),
+			holiday.Date.Format("02.01"),
+			holiday.Title())
+
+		for _, occurrence := range byDay[holiday.Date.Format(internalModels.StartDateLayout)] {
+			text += formatHolidayOccurrence(occurrence) + "\n"
+		}
+
+		text += "\n"
+	}
+
+	text += "💡 Налаштування «Пропускати у свята» змінюється на сторінці регулярної події."
+
+	return text, keyboards.AdminRecurringKeyboard()
+}
+
+func formatHolidayOccurrence(occurrence internalModels.Occurrence) string {
+	switch {
+	case occurrence.Holiday != "":
+		return fmt.Sprintf("   ⏭ %s — пропускається", html.EscapeString(occurrence.Event.Title))
+	case occurrence.IsCancelled:
+		return fmt.Sprintf("   ❌ %s — скасовано вручну", html.EscapeString(occurrence.Event.Title))
+	default:
+		return fmt.Sprintf("   ▶️ %s о %s — проводиться", html.EscapeString(occurrence.Event.Title), occurrence.Start.Format("15:04"))
+	}
+}
@@ -0,0 +1,115 @@
+// Package holidays computes Polish public holidays (dni ustawowo wolne od
+// pracy) without any external data source.
+package holidays
+
+import (
+	"sort"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+type Holiday struct {
+	Date time.Time
+	// Name is the Ukrainian name shown in the bot, LocalName the official
+	// Polish one.
+	Name      string
+	LocalName string
+}
+
+// Title returns the holiday name as shown to admins, e.g.
+// "Боже Тіло (Boże Ciało)".
+func (h Holiday) Title() string {
+	return h.Name + " (" + h.LocalName + ")"
+}
+
+// ForYear returns the public holidays of the given year in date order. Dates
+// are midnight in location.
+func ForYear(year int, location *time.Location) []Holiday {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, location)
+	}
+
+	easter := Easter(year, location)
+
+	result := []Holiday{
+		{date(time.January, 1), "Новий рік", "Nowy Rok"},
+		{date(time.January, 6), "Богоявлення", "Święto Trzech Króli"},
+		{easter, "Великдень", "Wielkanoc"},
+		{easter.AddDate(0, 0, 1), "Великодній понеділок", "Poniedziałek Wielkanocny"},
+		{date(time.May, 1), "Свято праці", "Święto Pracy"},
+		{date(time.May, 3), "День Конституції 3 травня", "Święto Konstytucji 3 Maja"},
+		{easter.AddDate(0, 0, 49), "Зіслання Святого Духа", "Zielone Świątki"},
+		{easter.AddDate(0, 0, 60), "Боже Тіло", "Boże Ciało"},
+		{date(time.August, 15), "Успіння Богородиці", "Wniebowzięcie NMP"},
+		{date(time.November, 1), "День всіх святих", "Wszystkich Świętych"},
+		{date(time.November, 11), "День незалежності", "Narodowe Święto Niepodległości"},
+		{date(time.December, 25), "Різдво", "Boże Narodzenie"},
+		{date(time.December, 26), "Другий день Різдва", "Drugi dzień Bożego Narodzenia"},
+	}
+
+	// Christmas Eve is a public holiday since 2025.
+	if year >= 2025 {
+		result = append(result, Holiday{date(time.December, 24), "Святвечір", "Wigilia"})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Date.Before(result[j].Date) })
+
+	return result
+}
+
+// Between returns the holidays falling on days within [from, to].
+func Between(from, to time.Time, location *time.Location) []Holiday {
+	from = from.In(location)
+	to = to.In(location)
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, location)
+
+	var result []Holiday
+
+	for year := from.Year(); year <= to.Year(); year++ {
+		for _, holiday := range ForYear(year, location) {
+			if holiday.Date.Before(first) || holiday.Date.After(to) {
+				continue
+			}
+			result = append(result, holiday)
+		}
+	}
+
+	return result
+}
+
+// Lookup reports whether the calendar day of t (in location) is a public
+// holiday.
+func Lookup(t time.Time, location *time.Location) (Holiday, bool) {
+	t = t.In(location)
+	day := t.Format(dateLayout)
+
+	for _, holiday := range ForYear(t.Year(), location) {
+		if holiday.Date.Format(dateLayout) == day {
+			return holiday, true
+		}
+	}
+
+	return Holiday{}, false
+}
+
+// Easter returns the date of Western Easter Sunday, using the anonymous
+// Gregorian algorithm (Meeus/Jones/Butcher).
+func Easter(year int, location *time.Location) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, location)
+}
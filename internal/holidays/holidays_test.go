@@ -0,0 +1,111 @@
+package holidays
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+)
+
+func TestMovableFeasts(t *testing.T) {
+	tests := []struct {
+		year                                    int
+		easter, easterMonday, pentecost, corpus string
+	}{
+		{2024, "2024-03-31", "2024-04-01", "2024-05-19", "2024-05-30"},
+		{2025, "2025-04-20", "2025-04-21", "2025-06-08", "2025-06-19"},
+		{2026, "2026-04-05", "2026-04-06", "2026-05-24", "2026-06-04"},
+		{2038, "2038-04-25", "2038-04-26", "2038-06-13", "2038-06-24"},
+	}
+
+	for _, tt := range tests {
+		if got := Easter(tt.year, clock.Location()).Format(dateLayout); got != tt.easter {
+			t.Errorf("Easter(%d) = %s, want %s", tt.year, got, tt.easter)
+		}
+
+		byName := make(map[string]string)
+		for _, holiday := range ForYear(tt.year, clock.Location()) {
+			byName[holiday.LocalName] = holiday.Date.Format(dateLayout)
+		}
+
+		want := map[string]string{
+			"Wielkanoc":                tt.easter,
+			"Poniedziałek Wielkanocny": tt.easterMonday,
+			"Zielone Świątki":          tt.pentecost,
+			"Boże Ciało":               tt.corpus,
+		}
+		for name, date := range want {
+			if byName[name] != date {
+				t.Errorf("%s %d = %s, want %s", name, tt.year, byName[name], date)
+			}
+		}
+	}
+}
+
+func TestForYear(t *testing.T) {
+	tests := []struct {
+		year  int
+		count int
+		eve   bool
+	}{
+		{2024, 13, false},
+		{2025, 14, true},
+		{2026, 14, true},
+	}
+
+	for _, tt := range tests {
+		holidays := ForYear(tt.year, clock.Location())
+		if len(holidays) != tt.count {
+			t.Errorf("ForYear(%d) has %d holidays, want %d", tt.year, len(holidays), tt.count)
+		}
+
+		_, eve := Lookup(clock.Date(tt.year, time.December, 24, 12, 0), clock.Location())
+		if eve != tt.eve {
+			t.Errorf("Christmas Eve %d is a holiday: %v, want %v", tt.year, eve, tt.eve)
+		}
+
+		for i := 1; i < len(holidays); i++ {
+			if !holidays[i-1].Date.Before(holidays[i].Date) {
+				t.Errorf("ForYear(%d) is not in date order: %s before %s", tt.year,
+					holidays[i-1].Date.Format(dateLayout), holidays[i].Date.Format(dateLayout))
+			}
+		}
+	}
+}
+
+func TestBetweenYearBoundary(t *testing.T) {
+	from := clock.Date(2025, time.December, 24, 15, 0)
+	to := clock.Date(2026, time.January, 6, 0, 0)
+
+	var got []string
+	for _, holiday := range Between(from, to, clock.Location()) {
+		got = append(got, holiday.Date.Format(dateLayout))
+	}
+
+	// The holiday on the day of from counts even though from is in the
+	// afternoon; to is inclusive.
+	want := "2025-12-24, 2025-12-25, 2025-12-26, 2026-01-01, 2026-01-06"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("Between() = %v, want %s", got, want)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{clock.Date(2026, time.November, 11, 18, 0), "Narodowe Święto Niepodległości"},
+		// 23:30 UTC on 10 November is already 11 November in Warsaw.
+		{time.Date(2026, time.November, 10, 23, 30, 0, 0, time.UTC), "Narodowe Święto Niepodległości"},
+		{clock.Date(2026, time.November, 12, 0, 0), ""},
+	}
+
+	for _, tt := range tests {
+		holiday, _ := Lookup(tt.t, clock.Location())
+		if holiday.LocalName != tt.want {
+			t.Errorf("Lookup(%s) = %q, want %q", tt.t, holiday.LocalName, tt.want)
+		}
+	}
+}
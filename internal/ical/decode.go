@@ -0,0 +1,247 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Event is a VEVENT read from an iCalendar file. Err is set when the event
+// cannot be used, e.g. because its start is missing or malformed.
+type Event struct {
+	// Line is the line number of BEGIN:VEVENT, for error reports.
+	Line        int
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Categories  string
+	URL         string
+	Start       time.Time
+	// End is DTEND, zero if missing. For all-day events it is exclusive: the
+	// day after the last day of the event.
+	End    time.Time
+	AllDay bool
+	// Recurring is set for events with an RRULE, which describe a series
+	// rather than a single event.
+	Recurring bool
+	// Err is a *PropertyError for events with a missing or malformed date.
+	Err error
+}
+
+// PropertyError reports a date property of an event that is missing or
+// cannot be parsed.
+type PropertyError struct {
+	// Property is the property name, e.g. "DTEND".
+	Property string
+	// Value is the raw value, empty if the property is missing.
+	Value string
+	Err   error
+}
+
+func (e *PropertyError) Error() string {
+	if e.Err == nil {
+		return "no " + e.Property
+	}
+	return fmt.Sprintf("invalid %s %q: %v", e.Property, e.Value, e.Err)
+}
+
+func (e *PropertyError) Unwrap() error {
+	return e.Err
+}
+
+// Decode reads the VEVENTs of an iCalendar file. Start times are returned
+// in the location given by TZID, in UTC for "Z" times and in defaultLocation
+// for floating times.
+func Decode(data []byte, defaultLocation *time.Location) ([]Event, error) {
+	lines, err := unfold(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current *Event
+	// nested counts open sub-components of the VEVENT, e.g. VALARM, whose
+	// properties must not override the event's own.
+	nested := 0
+
+	for _, line := range lines {
+		name, params, value, ok := splitContentLine(line.text)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &Event{Line: line.number}
+			nested = 0
+			continue
+		case name == "END" && value == "VEVENT":
+			if current != nil {
+				if current.Err == nil && current.Start.IsZero() {
+					current.Err = &PropertyError{Property: "DTSTART"}
+				}
+				events = append(events, *current)
+			}
+			current = nil
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		switch name {
+		case "BEGIN":
+			nested++
+			continue
+		case "END":
+			nested--
+			continue
+		}
+		if nested > 0 {
+			continue
+		}
+
+		switch name {
+		case "UID":
+			current.UID = value
+		case "SUMMARY":
+			current.Summary = unescape(value)
+		case "DESCRIPTION":
+			current.Description = unescape(value)
+		case "LOCATION":
+			current.Location = unescape(value)
+		case "CATEGORIES":
+			current.Categories = unescape(value)
+		case "URL":
+			current.URL = value
+		case "RRULE":
+			current.Recurring = true
+		case "DTSTART":
+			start, allDay, err := parseDateTime(params, value, defaultLocation)
+			if err != nil {
+				current.Err = &PropertyError{Property: "DTSTART", Value: value, Err: err}
+				continue
+			}
+			current.Start = start
+			current.AllDay = allDay
+		case "DTEND":
+			end, _, err := parseDateTime(params, value, defaultLocation)
+			if err != nil {
+				current.Err = &PropertyError{Property: "DTEND", Value: value, Err: err}
+				continue
+			}
+			current.End = end
+		}
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf("no VEVENT found")
+	}
+
+	return events, nil
+}
+
+type contentLine struct {
+	number int
+	text   string
+}
+
+// unfold joins folded lines and keeps the number of the first physical line
+// of each content line.
+func unfold(data []byte) ([]contentLine, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []contentLine
+	number := 0
+
+	for scanner.Scan() {
+		number++
+		text := strings.TrimRight(scanner.Text(), "\r")
+
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		if text == "" {
+			continue
+		}
+		lines = append(lines, contentLine{number: number, text: text})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	return lines, nil
+}
+
+// splitContentLine splits "NAME;PARAM=VALUE:value" into its parts. Parameter
+// names are upper-cased; quoted parameter values may contain ':'.
+func splitContentLine(line string) (name string, params map[string]string, value string, ok bool) {
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", false
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := strings.Split(head, ";")
+
+	params = make(map[string]string)
+	for _, part := range parts[1:] {
+		key, val, _ := strings.Cut(part, "=")
+		params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+
+	return strings.ToUpper(parts[0]), params, value, true
+}
+
+func parseDateTime(params map[string]string, value string, defaultLocation *time.Location) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len(dateLayout) {
+		t, err := time.ParseInLocation(dateLayout, value, defaultLocation)
+		return t, true, err
+	}
+
+	if utc, ok := strings.CutSuffix(value, "Z"); ok {
+		t, err := time.ParseInLocation(dateTimeLayout, utc, time.UTC)
+		return t, false, err
+	}
+
+	location := defaultLocation
+	if tzid := params["TZID"]; tzid != "" {
+		loaded, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown TZID %q", tzid)
+		}
+		location = loaded
+	}
+
+	t, err := time.ParseInLocation(dateTimeLayout, value, location)
+	return t, false, err
+}
+
+func unescape(value string) string {
+	return strings.NewReplacer(
+		`\\`, `\`,
+		`\;`, ";",
+		`\,`, ",",
+		`\n`, "\n",
+		`\N`, "\n",
+	).Replace(value)
+}
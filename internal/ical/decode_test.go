@@ -0,0 +1,216 @@
+package ical
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+)
+
+func TestDecodeRoundTrip(t *testing.T) {
+	entries := testEntries()
+
+	events, err := Decode(Encode("Слово Віри Варшава", entries, time.Now()), clock.Location())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != len(entries) {
+		t.Fatalf("decoded %d events, want %d", len(events), len(entries))
+	}
+
+	for i, event := range events {
+		entry := entries[i]
+
+		if event.Err != nil {
+			t.Errorf("event %d: %v", i, event.Err)
+		}
+		if event.UID != UID(&entry) {
+			t.Errorf("event %d: UID = %q, want %q", i, event.UID, UID(&entry))
+		}
+		if event.Summary != entry.Title || event.Description != entry.Description ||
+			event.Location != entry.Location || event.Categories != entry.Category ||
+			event.URL != entry.RegistrationURL {
+			t.Errorf("event %d: text properties changed:\ngot  %+v\nwant %+v", i, event, entry)
+		}
+		if event.AllDay != entry.AllDay {
+			t.Errorf("event %d: AllDay = %v, want %v", i, event.AllDay, entry.AllDay)
+		}
+		if !event.Start.Equal(entry.Start) {
+			t.Errorf("event %d: Start = %s, want %s", i, event.Start, entry.Start)
+		}
+	}
+
+	// The timed event ends 20:30 on the day of the switch to winter time.
+	if want := clock.Date(2026, time.October, 25, 20, 30); !events[0].End.Equal(want) {
+		t.Errorf("End = %s, want %s", events[0].End, want)
+	}
+	// DTEND of the camp is exclusive.
+	if want := clock.Date(2026, time.July, 18, 0, 0); !events[1].End.Equal(want) {
+		t.Errorf("End = %s, want %s", events[1].End, want)
+	}
+}
+
+func TestDecodeDates(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		lines  string
+		start  time.Time
+		allDay bool
+	}{
+		{
+			name:  "TZID",
+			lines: "DTSTART;TZID=America/New_York:20261025T180000",
+			start: time.Date(2026, time.October, 25, 18, 0, 0, 0, newYork),
+		},
+		{
+			name:  "quoted TZID",
+			lines: `DTSTART;TZID="Europe/Warsaw":20260329T100000`,
+			start: clock.Date(2026, time.March, 29, 10, 0),
+		},
+		{
+			name:  "UTC",
+			lines: "DTSTART:20261025T170000Z",
+			start: time.Date(2026, time.October, 25, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "floating time is Warsaw time",
+			lines: "DTSTART:20261025T180000",
+			start: clock.Date(2026, time.October, 25, 18, 0),
+		},
+		{
+			name:   "date",
+			lines:  "DTSTART;VALUE=DATE:20260715",
+			start:  clock.Date(2026, time.July, 15, 0, 0),
+			allDay: true,
+		},
+		{
+			name:   "date without VALUE",
+			lines:  "DTSTART:20260715",
+			start:  clock.Date(2026, time.July, 15, 0, 0),
+			allDay: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := Decode([]byte(calendarOf("SUMMARY:Подія\r\n"+tt.lines)), clock.Location())
+			if err != nil {
+				t.Fatal(err)
+			}
+			event := events[0]
+			if event.Err != nil {
+				t.Fatal(event.Err)
+			}
+			if !event.Start.Equal(tt.start) || event.AllDay != tt.allDay {
+				t.Errorf("Start = %s (all day: %v), want %s (all day: %v)", event.Start, event.AllDay, tt.start, tt.allDay)
+			}
+		})
+	}
+}
+
+func TestDecodeIgnoresNestedComponents(t *testing.T) {
+	data := calendarOf(strings.Join([]string{
+		"SUMMARY:Служіння",
+		"DTSTART:20261025T100000",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"DESCRIPTION:Нагадування",
+		"TRIGGER:-PT15M",
+		"END:VALARM",
+		"LOCATION:Зал",
+	}, "\r\n"))
+
+	events, err := Decode([]byte(data), clock.Location())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events[0].Description != "" {
+		t.Errorf("Description = %q, the VALARM description leaked into the event", events[0].Description)
+	}
+	if events[0].Location != "Зал" {
+		t.Errorf("Location = %q, properties after VALARM were lost", events[0].Location)
+	}
+}
+
+func TestDecodeUnfoldsLines(t *testing.T) {
+	data := "\ufeff" + calendarOf("SUMMARY:Моло\r\n дь\r\nDESCRIPTION:Перший\\, \n\tрядок\\nдругий\r\nDTSTART:20261025T180000")
+
+	events, err := Decode([]byte(data), clock.Location())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events[0].Summary != "Молодь" {
+		t.Errorf("Summary = %q", events[0].Summary)
+	}
+	if events[0].Description != "Перший, рядок\nдругий" {
+		t.Errorf("Description = %q", events[0].Description)
+	}
+}
+
+func TestDecodeQuotedParameterWithColon(t *testing.T) {
+	data := calendarOf(`DESCRIPTION;ALTREP="https://example.com/a:b":Опис` + "\r\nSUMMARY:Подія\r\nDTSTART:20261025T180000")
+
+	events, err := Decode([]byte(data), clock.Location())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events[0].Description != "Опис" {
+		t.Errorf("Description = %q", events[0].Description)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    string
+		property string
+		value    string
+	}{
+		{"missing start", "SUMMARY:Подія", "DTSTART", ""},
+		{"bad start", "DTSTART:2026-10-25", "DTSTART", "2026-10-25"},
+		{"bad end", "DTSTART:20261025T180000\r\nDTEND:20261025T25", "DTEND", "20261025T25"},
+		{"unknown TZID", "DTSTART;TZID=Mars/Olympus:20261025T180000", "DTSTART", "20261025T180000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := Decode([]byte(calendarOf(tt.lines)), clock.Location())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var propertyErr *PropertyError
+			if !errors.As(events[0].Err, &propertyErr) {
+				t.Fatalf("Err = %v, want a *PropertyError", events[0].Err)
+			}
+			if propertyErr.Property != tt.property || propertyErr.Value != tt.value {
+				t.Errorf("Err is about %s %q, want %s %q", propertyErr.Property, propertyErr.Value, tt.property, tt.value)
+			}
+		})
+	}
+
+	if _, err := Decode([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), clock.Location()); err == nil {
+		t.Error("Decode() of a calendar without events succeeded")
+	}
+}
+
+func TestDecodeRecurring(t *testing.T) {
+	events, err := Decode([]byte(calendarOf("DTSTART:20261025T100000\r\nRRULE:FREQ=WEEKLY")), clock.Location())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !events[0].Recurring {
+		t.Error("event with RRULE is not marked as recurring")
+	}
+}
+
+func calendarOf(event string) string {
+	return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\n" + event + "\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+}
@@ -0,0 +1,158 @@
+// Package ical renders calendar entries as RFC 5545 iCalendar files that
+// Google Calendar, Apple Calendar and Outlook can import.
+package ical
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/calendar"
+)
+
+const (
+	prodID    = "-//Slowo Wiary Warszawa//Telegram Bot//UK"
+	uidDomain = "slowo-wiary-warszawa-bot"
+	timezone  = "Europe/Warsaw"
+
+	// DefaultDuration is used as the length of timed events entered without
+	// an end time.
+	DefaultDuration = 2 * time.Hour
+
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+	maxLineOctets  = 75
+)
+
+// vtimezone describes Europe/Warsaw with the EU daylight saving rules, so
+// clients do not have to know the TZID.
+const vtimezone = `BEGIN:VTIMEZONE
+TZID:Europe/Warsaw
+X-LIC-LOCATION:Europe/Warsaw
+BEGIN:DAYLIGHT
+TZOFFSETFROM:+0100
+TZOFFSETTO:+0200
+TZNAME:CEST
+DTSTART:19700329T020000
+RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU
+END:DAYLIGHT
+BEGIN:STANDARD
+TZOFFSETFROM:+0200
+TZOFFSETTO:+0100
+TZNAME:CET
+DTSTART:19701025T030000
+RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU
+END:STANDARD
+END:VTIMEZONE`
+
+// Encode returns a VCALENDAR with one VEVENT per entry. name is shown by
+// clients that support X-WR-CALNAME; now is used for DTSTAMP.
+func Encode(name string, entries []calendar.Entry, now time.Time) []byte {
+	var w writer
+
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + prodID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	if name != "" {
+		w.line("X-WR-CALNAME:" + escape(name))
+		w.line("X-WR-TIMEZONE:" + timezone)
+	}
+
+	for _, line := range strings.Split(vtimezone, "\n") {
+		w.line(line)
+	}
+
+	stamp := now.UTC().Format(dateTimeLayout) + "Z"
+	for i := range entries {
+		writeEvent(&w, &entries[i], stamp)
+	}
+
+	w.line("END:VCALENDAR")
+
+	return []byte(w.String())
+}
+
+func writeEvent(w *writer, entry *calendar.Entry, stamp string) {
+	w.line("BEGIN:VEVENT")
+	w.line("UID:" + UID(entry))
+	w.line("DTSTAMP:" + stamp)
+
+	end := entry.End
+
+	if entry.AllDay {
+		if end.IsZero() {
+			end = entry.Start.AddDate(0, 0, 1)
+		}
+		w.line("DTSTART;VALUE=DATE:" + entry.Start.Format(dateLayout))
+		w.line("DTEND;VALUE=DATE:" + end.Format(dateLayout))
+	} else {
+		if end.IsZero() {
+			end = entry.Start.Add(DefaultDuration)
+		}
+		w.line(fmt.Sprintf("DTSTART;TZID=%s:%s", timezone, entry.Start.Format(dateTimeLayout)))
+		w.line(fmt.Sprintf("DTEND;TZID=%s:%s", timezone, end.Format(dateTimeLayout)))
+	}
+
+	w.line("SUMMARY:" + escape(entry.Title))
+	if entry.Description != "" {
+		w.line("DESCRIPTION:" + escape(entry.Description))
+	}
+	if entry.Location != "" {
+		w.line("LOCATION:" + escape(entry.Location))
+	}
+	if entry.Category != "" {
+		w.line("CATEGORIES:" + escape(entry.Category))
+	}
+	if entry.RegistrationURL != "" {
+		w.line("URL:" + entry.RegistrationURL)
+	}
+
+	w.line("END:VEVENT")
+}
+
+// UID identifies the entry across exports, so re-importing a file updates
+// the events instead of duplicating them.
+func UID(entry *calendar.Entry) string {
+	if entry.IsRecurring() {
+		date := strings.ReplaceAll(entry.Occurrence.ScheduledDate, "-", "")
+		return fmt.Sprintf("recurring-%d-%s@%s", entry.Occurrence.Event.ID, date, uidDomain)
+	}
+	return fmt.Sprintf("event-%d@%s", entry.Event.ID, uidDomain)
+}
+
+// escape escapes a TEXT value (RFC 5545, section 3.3.11).
+func escape(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// writer collects content lines, folding them at 75 octets without splitting
+// UTF-8 characters (RFC 5545, section 3.1).
+type writer struct {
+	strings.Builder
+}
+
+func (w *writer) line(content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.WriteString(content[:cut])
+		w.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts as an octet.
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(content)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,203 @@
+package ical
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/calendar"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// testEntries covers a timed event with a long Cyrillic description, an
+// all-day camp, a one-day all-day event without an end and a recurring
+// occurrence.
+func testEntries() []calendar.Entry {
+	service := calendar.NewService()
+
+	timed := models.Event{
+		ID:    12,
+		Title: "Молодіжна зустріч; тема: «Віра, надія, любов»",
+		Description: "Запрошуємо всіх бажаючих на вечір спілкування, хвали та молитви.\n" +
+			`Візьміть із собою Біблію і друзів! Шлях: C:\церква`,
+		Date:            clock.Date(2026, time.October, 25, 18, 0),
+		Location:        ptr("вул. Марszałkowska 10, Варшава"),
+		Category:        ptr("Молодь"),
+		RegistrationURL: ptr("https://example.com/register?id=12&lang=uk"),
+	}
+	end := clock.Date(2026, time.October, 25, 20, 30)
+	timed.EndDate = &end
+
+	camp := models.Event{
+		ID:     13,
+		Title:  "Табір",
+		Date:   clock.Date(2026, time.July, 15, 0, 0),
+		AllDay: true,
+	}
+	lastDay := clock.Date(2026, time.July, 17, 0, 0)
+	camp.EndDate = &lastDay
+
+	day := models.Event{
+		ID:     14,
+		Title:  "День подяки",
+		Date:   clock.Date(2026, time.March, 29, 0, 0),
+		AllDay: true,
+	}
+
+	occurrence := models.Occurrence{
+		Event:         &models.RecurringEvent{ID: 3, Title: "Недільне служіння"},
+		ScheduledDate: "2026-03-29",
+		Start:         clock.Date(2026, time.March, 29, 11, 0),
+		Location:      "Зал",
+	}
+
+	return []calendar.Entry{
+		service.EventEntry(&timed),
+		service.EventEntry(&camp),
+		service.EventEntry(&day),
+		{
+			Title:      occurrence.Event.Title,
+			Start:      occurrence.Start,
+			Location:   occurrence.Location,
+			Occurrence: &occurrence,
+		},
+	}
+}
+
+func TestEncodeGolden(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 9, 30, 0, 0, time.UTC)
+	got := Encode("Слово Віри Варшава", testEntries(), now)
+
+	golden := filepath.Join("testdata", "calendar.ics")
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Encode() differs from %s:\n%s", golden, got)
+	}
+}
+
+func TestEncodeFolding(t *testing.T) {
+	data := Encode("", testEntries(), time.Now())
+
+	if !bytes.HasSuffix(data, []byte("\r\n")) {
+		t.Error("output does not end with CRLF")
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\r\n"), "\r\n")
+	for i, line := range lines {
+		if len(line) > maxLineOctets {
+			t.Errorf("line %d is %d octets long: %q", i+1, len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %d splits a UTF-8 character: %q", i+1, line)
+		}
+		if strings.ContainsAny(line, "\r\n") {
+			t.Errorf("line %d contains a bare line break: %q", i+1, line)
+		}
+	}
+}
+
+func TestWriterFoldsMultiByteText(t *testing.T) {
+	// 40 Cyrillic letters are 80 octets.
+	content := "DESCRIPTION:" + strings.Repeat("ж", 40)
+
+	var w writer
+	w.line(content)
+
+	lines := strings.Split(strings.TrimSuffix(w.String(), "\r\n"), "\r\n ")
+	if len(lines) != 2 {
+		t.Fatalf("line folded into %d parts, want 2: %q", len(lines), w.String())
+	}
+	// "DESCRIPTION:" is 12 octets; 31 letters fit into the other 63.
+	if want := "DESCRIPTION:" + strings.Repeat("ж", 31); lines[0] != want {
+		t.Errorf("first line = %q, want %q", lines[0], want)
+	}
+	if strings.Join(lines, "") != content {
+		t.Errorf("unfolded line = %q, want %q", strings.Join(lines, ""), content)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		value, want string
+	}{
+		{"Віра, надія; любов", `Віра\, надія\; любов`},
+		{`C:\церква`, `C:\\церква`},
+		{"перший\nдругий", `перший\nдругий`},
+		{"перший\r\nдругий", `перший\nдругий`},
+		{`\,`, `\\\,`},
+	}
+
+	for _, tt := range tests {
+		if got := escape(tt.value); got != tt.want {
+			t.Errorf("escape(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeAllDayEndIsExclusive(t *testing.T) {
+	data := string(Encode("", testEntries()[1:3], time.Now()))
+
+	for _, want := range []string{
+		// The camp lasts 15–17 July; DTEND is the day after.
+		"DTSTART;VALUE=DATE:20260715\r\nDTEND;VALUE=DATE:20260718\r\n",
+		// A one-day event without an end lasts until the next day.
+		"DTSTART;VALUE=DATE:20260329\r\nDTEND;VALUE=DATE:20260330\r\n",
+	} {
+		if !strings.Contains(data, want) {
+			t.Errorf("output does not contain %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestEncodeTimezone(t *testing.T) {
+	data := string(Encode("", testEntries()[:1], time.Now()))
+
+	begin := strings.Index(data, "BEGIN:VTIMEZONE\r\n")
+	end := strings.Index(data, "END:VTIMEZONE\r\n")
+	if begin < 0 || end < begin {
+		t.Fatalf("no VTIMEZONE block:\n%s", data)
+	}
+	if event := strings.Index(data, "BEGIN:VEVENT"); event < end {
+		t.Error("VTIMEZONE must come before the events")
+	}
+
+	block := data[begin:end]
+	for _, want := range []string{
+		"TZID:Europe/Warsaw\r\n",
+		"TZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\nTZNAME:CEST\r\n",
+		"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU\r\n",
+		"TZOFFSETFROM:+0200\r\nTZOFFSETTO:+0100\r\nTZNAME:CET\r\n",
+		"RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU\r\n",
+	} {
+		if !strings.Contains(block, want) {
+			t.Errorf("VTIMEZONE does not contain %q", want)
+		}
+	}
+
+	// The event of 25 October, the day of the switch to winter time, keeps
+	// its Warsaw wall-clock time.
+	if !strings.Contains(data, "DTSTART;TZID=Europe/Warsaw:20261025T180000\r\n") {
+		t.Errorf("event start is not in Warsaw time:\n%s", data)
+	}
+}
+
+func ptr(value string) *string {
+	return &value
+}
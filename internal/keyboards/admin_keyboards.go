@@ -1,6 +1,13 @@
 package keyboards
 
-import "github.com/go-telegram/bot/models"
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
 
 func AdminPanelKeyboard() *models.InlineKeyboardMarkup {
 	return &models.InlineKeyboardMarkup{
@@ -13,8 +20,13 @@ func AdminPanelKeyboard() *models.InlineKeyboardMarkup {
 				{Text: "📊 Користувачі", CallbackData: "admin_users"},
 				{Text: "💾 Експорт БД", CallbackData: "admin_export_db"},
 			},
+			{
+				{Text: "📆 Експорт календаря (.ics)", CallbackData: "admin_export_ics"},
+				{Text: "🏷 Категорії", CallbackData: "admin_categories"},
+			},
 			{
 				{Text: "📢 Розсилка", CallbackData: "admin_broadcast"},
+				{Text: "🔁 Регулярні події", CallbackData: "admin_recurring"},
 			},
 			{
 				{Text: "🏠 Головне меню", CallbackData: "back_to_start"},
@@ -28,10 +40,19 @@ func AdminEventsListKeyboard() *models.InlineKeyboardMarkup {
 		InlineKeyboard: [][]models.InlineKeyboardButton{
 			{
 				{Text: "➕ Додати подію", CallbackData: "admin_add_event"},
+				{Text: "📥 Імпорт з файлу", CallbackData: "admin_event_import"},
+			},
+			{
+				{Text: "✏️ Редагувати", CallbackData: "admin_event_edit"},
+				{Text: "🔔 Нагадування", CallbackData: "admin_event_reminders"},
 			},
 			{
+				{Text: "📑 Дублювати", CallbackData: "admin_event_clone"},
 				{Text: "🗑️ Видалити подію", CallbackData: "admin_delete_event"},
 			},
+			{
+				{Text: "🗄 Архів минулих подій", CallbackData: "admin_archive"},
+			},
 			{
 				{Text: "◀️ Назад", CallbackData: "admin_panel"},
 				{Text: "🏠 Головне меню", CallbackData: "back_to_start"},
@@ -40,6 +61,153 @@ func AdminEventsListKeyboard() *models.InlineKeyboardMarkup {
 	}
 }
 
+func EventCreatedKeyboard(event *internalModels.Event) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+
+	if announce := EventAnnounceButton(event); announce != nil {
+		rows = append(rows, []models.InlineKeyboardButton{*announce})
+	}
+
+	rows = append(rows,
+		[]models.InlineKeyboardButton{
+			{Text: "🔔 Налаштувати нагадування", CallbackData: fmt.Sprintf("admin_event_reminders:%d", event.ID)},
+		},
+		[]models.InlineKeyboardButton{
+			{Text: "✏️ Редагувати", CallbackData: fmt.Sprintf("admin_event_edit:%d", event.ID)},
+		},
+		[]models.InlineKeyboardButton{
+			{Text: "◀️ До адмін-панелі", CallbackData: "admin_panel"},
+		},
+	)
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// EventAnnounceButton offers to announce a published event to subscribers.
+// It returns nil for drafts and events that were already announced.
+func EventAnnounceButton(event *internalModels.Event) *models.InlineKeyboardButton {
+	if !event.IsPublished || event.AnnouncedAt != nil {
+		return nil
+	}
+	return &models.InlineKeyboardButton{
+		Text:         "📣 Анонсувати підписникам",
+		CallbackData: fmt.Sprintf("admin_event_announce:%d", event.ID),
+	}
+}
+
+// EventAnnounceConfirmKeyboard confirms sending the announcement of the event.
+func EventAnnounceConfirmKeyboard(eventID int) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: "✅ Так, надіслати", CallbackData: fmt.Sprintf("admin_event_announce_confirm:%d", eventID)},
+				{Text: "❌ Скасувати", CallbackData: fmt.Sprintf("admin_event_edit:%d", eventID)},
+			},
+		},
+	}
+}
+
+// AdminArchiveKeyboard moves between the months of the past events archive.
+// prev and next are the nearest months with events, zero at either end.
+func AdminArchiveKeyboard(prev, next time.Time) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+
+	if nav := archiveNavigationRow("admin_archive", prev, next); len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "◀️ До списку подій", CallbackData: "admin_list_events"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// EventRemindersKeyboard lists the reminder presets as toggles; enabled
+// offsets are marked with ✅.
+func EventRemindersKeyboard(eventID int, enabled map[int]bool) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+	var row []models.InlineKeyboardButton
+
+	for _, minutes := range internalModels.ReminderOffsetPresets {
+		mark := "▫️"
+		if enabled[minutes] {
+			mark = "✅"
+		}
+
+		row = append(row, models.InlineKeyboardButton{
+			Text:         fmt.Sprintf("%s %s", mark, messages.FormatReminderOffset(minutes)),
+			CallbackData: fmt.Sprintf("admin_event_reminder_toggle:%d:%d", eventID, minutes),
+		})
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "◀️ До списку подій", CallbackData: "admin_list_events"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func EventAttendeesKeyboard(eventID int) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: "📥 Завантажити CSV", CallbackData: fmt.Sprintf("admin_event_attendees_csv:%d", eventID)},
+				{Text: "🚪 Check-in", CallbackData: fmt.Sprintf("admin_event_checkin:%d:0", eventID)},
+			},
+			{
+				{Text: "◀️ До події", CallbackData: fmt.Sprintf("admin_event_edit:%d", eventID)},
+			},
+		},
+	}
+}
+
+// EventCheckInKeyboard lists one page of registrants; pressing a name marks
+// or unmarks their arrival.
+func EventCheckInKeyboard(eventID int, attendees []internalModels.Attendee, page, pages int) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+
+	for _, attendee := range attendees {
+		mark := "⬜️"
+		if attendee.IsCheckedIn() {
+			mark = "✅"
+		}
+		text := fmt.Sprintf("%s %s", mark, attendee.DisplayName())
+		if attendee.Status == internalModels.RegistrationWaitlist {
+			text += " ⏳"
+		}
+
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: text, CallbackData: fmt.Sprintf("admin_event_checkin_toggle:%d:%d:%d", eventID, attendee.ID, page)},
+		})
+	}
+
+	if pages > 1 {
+		var nav []models.InlineKeyboardButton
+		if page > 0 {
+			nav = append(nav, models.InlineKeyboardButton{Text: "◀️", CallbackData: fmt.Sprintf("admin_event_checkin:%d:%d", eventID, page-1)})
+		}
+		nav = append(nav, models.InlineKeyboardButton{Text: fmt.Sprintf("%d / %d", page+1, pages), CallbackData: fmt.Sprintf("admin_event_checkin:%d:%d", eventID, page)})
+		if page < pages-1 {
+			nav = append(nav, models.InlineKeyboardButton{Text: "▶️", CallbackData: fmt.Sprintf("admin_event_checkin:%d:%d", eventID, page+1)})
+		}
+		rows = append(rows, nav)
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "◀️ До учасників", CallbackData: fmt.Sprintf("admin_event_attendees:%d", eventID)},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
 func AdminUsersKeyboard() *models.InlineKeyboardMarkup {
 	return &models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]models.InlineKeyboardButton{
@@ -100,6 +268,201 @@ func BackToAdminPanelKeyboard() *models.InlineKeyboardMarkup {
 	}
 }
 
+func EventEditFieldsKeyboard(event *internalModels.Event) *models.InlineKeyboardMarkup {
+	field := func(text, name string) models.InlineKeyboardButton {
+		return models.InlineKeyboardButton{
+			Text:         text,
+			CallbackData: fmt.Sprintf("admin_event_edit_field:%d:%s", event.ID, name),
+		}
+	}
+
+	publish := []models.InlineKeyboardButton{
+		{Text: "🙈 Зняти з публікації", CallbackData: fmt.Sprintf("admin_event_pub_off:%d", event.ID)},
+	}
+	if !event.IsPublished {
+		publish = []models.InlineKeyboardButton{
+			{Text: "📢 Опублікувати", CallbackData: fmt.Sprintf("admin_event_pub_on:%d", event.ID)},
+			{Text: "⏰ Запланувати", CallbackData: fmt.Sprintf("admin_event_pub_schedule:%d", event.ID)},
+		}
+	}
+
+	rows := [][]models.InlineKeyboardButton{
+		{field("Назва", "title"), field("Дата", "date")},
+		{field("Опис", "description"), field("Місце", "location")},
+		{field("Категорія", "category"), field("Реєстрація", "registration_url")},
+		{field("Кількість місць", "capacity"), field("Постер", "image")},
+		{field("Фотозвіт", "report_url")},
+		{
+			{Text: "👥 Учасники", CallbackData: fmt.Sprintf("admin_event_attendees:%d", event.ID)},
+			{Text: "📑 Дублювати", CallbackData: fmt.Sprintf("admin_event_clone:%d", event.ID)},
+		},
+		publish,
+	}
+
+	if announce := EventAnnounceButton(event); announce != nil {
+		rows = append(rows, []models.InlineKeyboardButton{*announce})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "◀️ До списку подій", CallbackData: "admin_list_events"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// EventConfirmKeyboard is shown with the preview at the last step of the
+// add-event dialog.
+func EventConfirmKeyboard() *models.InlineKeyboardMarkup {
+	field := func(text, name string) models.InlineKeyboardButton {
+		return models.InlineKeyboardButton{
+			Text:         "✏️ " + text,
+			CallbackData: "admin_event_step:" + name,
+		}
+	}
+
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{field("Назва", "title"), field("Дата", "date")},
+			{field("Опис", "description"), field("Місце", "location")},
+			{field("Категорія", "category"), field("Реєстрація", "registration_url")},
+			{field("Постер", "image")},
+			{
+				{Text: "✅ Опублікувати", CallbackData: "admin_event_pub_now"},
+			},
+			{
+				{Text: "📝 Зберегти як чернетку", CallbackData: "admin_event_pub_draft"},
+				{Text: "⏰ Запланувати", CallbackData: "admin_event_pub_schedule"},
+			},
+			{
+				{Text: "◀️ Назад", CallbackData: "admin_event_step_back"},
+				{Text: "❌ Скасувати", CallbackData: "admin_event_pub_cancel"},
+			},
+		},
+	}
+}
+
+func EventDialogBackKeyboard() *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: "◀️ Назад", CallbackData: "admin_event_step_back"},
+			},
+		},
+	}
+}
+
+func EventEditConfirmKeyboard() *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: "✅ Зберегти", CallbackData: "admin_event_edit_save"},
+				{Text: "❌ Скасувати", CallbackData: "admin_event_edit_cancel"},
+			},
+		},
+	}
+}
+
+// EventImportConfirmKeyboard is shown with the import preview. Without valid
+// events only cancelling is possible.
+func EventImportConfirmKeyboard(count int) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+
+	if count > 0 {
+		rows = append(rows,
+			[]models.InlineKeyboardButton{
+				{Text: fmt.Sprintf("📝 Імпортувати як чернетки (%d)", count), CallbackData: "admin_event_import_draft"},
+			},
+			[]models.InlineKeyboardButton{
+				{Text: fmt.Sprintf("📢 Імпортувати й опублікувати (%d)", count), CallbackData: "admin_event_import_publish"},
+			},
+		)
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "❌ Скасувати", CallbackData: "admin_event_import_cancel"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// EventCategoryKeyboard offers the managed categories as buttons with
+// callback data "<callbackPrefix>:<category id>"; "без категорії" sends id 0.
+func EventCategoryKeyboard(categories []internalModels.Category, callbackPrefix string) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+	var row []models.InlineKeyboardButton
+
+	for _, category := range categories {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         category.Label(),
+			CallbackData: fmt.Sprintf("%s:%d", callbackPrefix, category.ID),
+		})
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "🚫 Без категорії", CallbackData: callbackPrefix + ":0"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func AdminCategoriesKeyboard(categories []internalModels.Category) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+
+	for _, category := range categories {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: category.Label(), CallbackData: fmt.Sprintf("admin_category:%d", category.ID)},
+		})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "➕ Додати категорію", CallbackData: "admin_category_add"},
+	}, []models.InlineKeyboardButton{
+		{Text: "◀️ Назад", CallbackData: "admin_panel"},
+		{Text: "🏠 Головне меню", CallbackData: "back_to_start"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func AdminCategoryKeyboard(categoryID int) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: "✏️ Назва", CallbackData: fmt.Sprintf("admin_category_rename:%d", categoryID)},
+				{Text: "😀 Емодзі", CallbackData: fmt.Sprintf("admin_category_emoji:%d", categoryID)},
+			},
+			{
+				{Text: "⬆️ Вище", CallbackData: fmt.Sprintf("admin_category_up:%d", categoryID)},
+				{Text: "⬇️ Нижче", CallbackData: fmt.Sprintf("admin_category_down:%d", categoryID)},
+			},
+			{
+				{Text: "🗑️ Видалити", CallbackData: fmt.Sprintf("admin_category_delete:%d", categoryID)},
+			},
+			{
+				{Text: "◀️ До категорій", CallbackData: "admin_categories"},
+			},
+		},
+	}
+}
+
+func CategoryDeleteConfirmKeyboard(categoryID int) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: "✅ Так, видалити", CallbackData: fmt.Sprintf("admin_category_delete_confirm:%d", categoryID)},
+				{Text: "❌ Ні", CallbackData: fmt.Sprintf("admin_category:%d", categoryID)},
+			},
+		},
+	}
+}
+
 func DeleteConfirmKeyboard() *models.InlineKeyboardMarkup {
 	return &models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]models.InlineKeyboardButton{
@@ -110,3 +473,229 @@ func DeleteConfirmKeyboard() *models.InlineKeyboardMarkup {
 		},
 	}
 }
+
+func AdminRecurringKeyboard() *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: "🔁 Регулярні події", CallbackData: "admin_recurring"},
+			},
+			{
+				{Text: "◀️ До адмін-панелі", CallbackData: "admin_panel"},
+			},
+		},
+	}
+}
+
+func AdminRecurringListKeyboard(events []internalModels.RecurringEvent) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+
+	for _, event := range events {
+		status := "✅"
+		if !event.IsActive {
+			status = "⏸"
+		}
+		rows = append(rows, []models.InlineKeyboardButton{
+			{
+				Text:         fmt.Sprintf("%s %s", status, event.Title),
+				CallbackData: fmt.Sprintf("admin_recurring_view:%d", event.ID),
+			},
+		})
+	}
+
+	rows = append(rows,
+		[]models.InlineKeyboardButton{
+			{Text: "➕ Додати регулярну подію", CallbackData: "admin_recurring_add"},
+		},
+		[]models.InlineKeyboardButton{
+			{Text: "🇵🇱 Свята на 3 місяці", CallbackData: "admin_recurring_holidays"},
+		},
+		[]models.InlineKeyboardButton{
+			{Text: "◀️ Назад", CallbackData: "admin_panel"},
+			{Text: "🏠 Головне меню", CallbackData: "back_to_start"},
+		},
+	)
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func AdminRecurringEventKeyboard(event *internalModels.RecurringEvent) *models.InlineKeyboardMarkup {
+	toggle := models.InlineKeyboardButton{
+		Text:         "⏸ Призупинити",
+		CallbackData: fmt.Sprintf("admin_recurring_pause:%d", event.ID),
+	}
+	if !event.IsActive {
+		toggle = models.InlineKeyboardButton{
+			Text:         "▶️ Відновити",
+			CallbackData: fmt.Sprintf("admin_recurring_resume:%d", event.ID),
+		}
+	}
+
+	holidayPolicy := models.InlineKeyboardButton{
+		Text:         "🇵🇱 Пропускати у свята",
+		CallbackData: fmt.Sprintf("admin_recurring_holiday_skip:%d", event.ID),
+	}
+	if event.SkipsHolidays() {
+		holidayPolicy = models.InlineKeyboardButton{
+			Text:         "🇵🇱 Проводити у свята",
+			CallbackData: fmt.Sprintf("admin_recurring_holiday_run:%d", event.ID),
+		}
+	}
+
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				toggle,
+				{Text: "✏️ Редагувати", CallbackData: fmt.Sprintf("admin_recurring_edit:%d", event.ID)},
+			},
+			{
+				{Text: "📆 Окремі дати", CallbackData: fmt.Sprintf("admin_recurring_dates:%d", event.ID)},
+				{Text: "🗑️ Видалити", CallbackData: fmt.Sprintf("admin_recurring_delete:%d", event.ID)},
+			},
+			{
+				holidayPolicy,
+			},
+			{
+				{Text: "◀️ До списку", CallbackData: "admin_recurring"},
+			},
+		},
+	}
+}
+
+// RecurringEditFieldsKeyboard lists the editable fields of the series. The
+// day of the week is hidden for series with an RRULE, which sets it instead.
+func RecurringEditFieldsKeyboard(event *internalModels.RecurringEvent) *models.InlineKeyboardMarkup {
+	field := func(text, name string) models.InlineKeyboardButton {
+		return models.InlineKeyboardButton{
+			Text:         text,
+			CallbackData: fmt.Sprintf("admin_recurring_edit_field:%d:%s", event.ID, name),
+		}
+	}
+
+	schedule := []models.InlineKeyboardButton{field("Час події", "time")}
+	if event.RRule == "" {
+		schedule = []models.InlineKeyboardButton{field("День тижня", "day"), field("Час події", "time")}
+	}
+
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{field("Назва", "title"), field("Опис", "description")},
+			schedule,
+			{field("День нагадування", "reminder_offset"), field("Час нагадування", "reminder_time")},
+			{field("Повторення", "rrule"), field("Дата початку", "start_date")},
+			{field("Місце", "location"), field("Категорія", "category")},
+			{field("Реєстрація", "registration_url")},
+			{
+				{Text: "◀️ Назад", CallbackData: fmt.Sprintf("admin_recurring_view:%d", event.ID)},
+			},
+		},
+	}
+}
+
+func RecurringDeleteConfirmKeyboard(eventID int) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: "✅ Так, видалити", CallbackData: fmt.Sprintf("admin_recurring_delete_confirm:%d", eventID)},
+				{Text: "❌ Скасувати", CallbackData: fmt.Sprintf("admin_recurring_view:%d", eventID)},
+			},
+		},
+	}
+}
+
+func RecurringOccurrencesKeyboard(eventID int, occurrences []internalModels.Occurrence) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+	var row []models.InlineKeyboardButton
+
+	for _, occurrence := range occurrences {
+		label := occurrence.Start.Format("02.01 15:04")
+		if occurrence.IsCancelled {
+			label = "❌ " + occurrence.Start.Format("02.01")
+		} else if occurrence.IsChanged {
+			label = "🔀 " + label
+		}
+
+		row = append(row, models.InlineKeyboardButton{
+			Text:         label,
+			CallbackData: fmt.Sprintf("admin_recurring_occ:%d:%s", eventID, occurrence.ScheduledDate),
+		})
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "◀️ Назад", CallbackData: fmt.Sprintf("admin_recurring_view:%d", eventID)},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func RecurringOccurrenceKeyboard(eventID int, scheduledDate string, hasException bool) *models.InlineKeyboardMarkup {
+	action := func(text, name string) models.InlineKeyboardButton {
+		return models.InlineKeyboardButton{
+			Text:         text,
+			CallbackData: fmt.Sprintf("admin_recurring_occ_%s:%d:%s", name, eventID, scheduledDate),
+		}
+	}
+
+	rows := [][]models.InlineKeyboardButton{
+		{action("❌ Скасувати цю дату", "cancel")},
+		{action("🕒 Змінити час", "time"), action("📍 Змінити місце", "place")},
+		{action("📅 Перенести на іншу дату", "date")},
+	}
+	if hasException {
+		rows = append(rows, []models.InlineKeyboardButton{action("↩️ Як у розкладі", "reset")})
+	}
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "◀️ До дат", CallbackData: fmt.Sprintf("admin_recurring_dates:%d", eventID)},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// RecurringWeekdayKeyboard builds weekday buttons whose callback data is
+// "<callbackPrefix>:<day>".
+func RecurringWeekdayKeyboard(callbackPrefix string) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+	var row []models.InlineKeyboardButton
+
+	// Monday first, as the week is read in Poland and Ukraine.
+	for _, day := range []int{1, 2, 3, 4, 5, 6, 0} {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         internalModels.WeekdayName(day),
+			CallbackData: fmt.Sprintf("%s:%d", callbackPrefix, day),
+		})
+		if len(row) == 3 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// RecurringReminderOffsetKeyboard builds reminder offset buttons whose callback
+// data is "<callbackPrefix>:<offset>".
+func RecurringReminderOffsetKeyboard(callbackPrefix string) *models.InlineKeyboardMarkup {
+	option := func(text string, offset int) models.InlineKeyboardButton {
+		return models.InlineKeyboardButton{
+			Text:         text,
+			CallbackData: fmt.Sprintf("%s:%d", callbackPrefix, offset),
+		}
+	}
+
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{option("У день події", 0), option("За 1 день", -1)},
+			{option("За 2 дні", -2), option("За 3 дні", -3)},
+		},
+	}
+}
@@ -1,8 +1,12 @@
 package keyboards
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/go-telegram/bot/models"
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
 )
 
 func AboutUsKeyboard() *models.InlineKeyboardMarkup {
@@ -81,6 +85,229 @@ func BackToMinistryKeyboard() *models.InlineKeyboardMarkup {
 	}
 }
 
+// EventsPageKeyboard lists the events of one page as buttons opening their
+// cards, numbered from first+1, with ◀️/▶️ navigation between pages. category
+// is the category the list is filtered by, or nil for all events.
+func EventsPageKeyboard(events []internalModels.Event, first int, page int, pages int, category *internalModels.Category) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+
+	for i, event := range events {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{
+				Text:         fmt.Sprintf("%d. %s", first+i+1, event.Title),
+				CallbackData: fmt.Sprintf("event:%d", event.ID),
+			},
+		})
+	}
+
+	pageData := func(page int) string {
+		if category == nil {
+			return fmt.Sprintf("events_page:%d", page)
+		}
+		return fmt.Sprintf("events_page:%d:%d", page, category.ID)
+	}
+
+	if pages > 1 {
+		var nav []models.InlineKeyboardButton
+		if page > 0 {
+			nav = append(nav, models.InlineKeyboardButton{Text: "◀️", CallbackData: pageData(page - 1)})
+		}
+		nav = append(nav, models.InlineKeyboardButton{Text: fmt.Sprintf("%d / %d", page+1, pages), CallbackData: pageData(page)})
+		if page < pages-1 {
+			nav = append(nav, models.InlineKeyboardButton{Text: "▶️", CallbackData: pageData(page + 1)})
+		}
+		rows = append(rows, nav)
+	}
+
+	if category == nil {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: "🏷 Фільтр за категорією", CallbackData: "events_categories"},
+			{Text: "📚 Минулі події", CallbackData: "past_events"},
+		})
+	} else {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: "🏷 Інша категорія", CallbackData: "events_categories"},
+			{Text: "✖️ Усі події", CallbackData: "events_page:0"},
+		})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "🗓 Календар", CallbackData: "calendar_week:0"},
+		{Text: messages.NavigationButtons["back_to_start"], CallbackData: "back_to_start"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// EventsCategoryFilterKeyboard lets users pick the category to filter the
+// events list by.
+func EventsCategoryFilterKeyboard(categories []internalModels.Category) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+
+	for _, category := range categories {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: category.Label(), CallbackData: fmt.Sprintf("events_page:0:%d", category.ID)},
+		})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "📋 Усі події", CallbackData: "events_page:0"},
+	}, []models.InlineKeyboardButton{
+		{Text: messages.NavigationButtons["back_to_start"], CallbackData: "back_to_start"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// EventCardKeyboard builds the buttons of an event card. registration is the
+// user's current registration (nil if none); canRegister is false for events
+// that have already started. The share button is left out when shareURL is
+// empty.
+func EventCardKeyboard(event *internalModels.Event, shareURL string, registration *internalModels.EventRegistration, canRegister bool) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+
+	switch {
+	case registration != nil && registration.Status == internalModels.RegistrationWaitlist:
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: "🚫 Вийти з листа очікування", CallbackData: fmt.Sprintf("rsvp_cancel:%d", event.ID)},
+		})
+	case registration != nil:
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: "🚫 Скасувати реєстрацію", CallbackData: fmt.Sprintf("rsvp_cancel:%d", event.ID)},
+		})
+	case canRegister:
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: "✅ Я прийду", CallbackData: fmt.Sprintf("rsvp:%d", event.ID)},
+		})
+	}
+
+	if event.RegistrationURL != nil && *event.RegistrationURL != "" {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: "📝 Зареєструватися", URL: *event.RegistrationURL},
+		})
+	}
+
+	if event.HasCoordinates() {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: "📍 Показати на мапі", CallbackData: fmt.Sprintf("map:%d", event.ID)},
+		})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "📆 Додати в календар", CallbackData: fmt.Sprintf("ics:%d", event.ID)},
+	})
+
+	if shareURL != "" {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: "📤 Поділитися", URL: shareURL},
+		})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: messages.NavigationButtons["back"], CallbackData: "events"},
+		{Text: messages.NavigationButtons["back_to_start"], CallbackData: "back_to_start"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// EventAnnouncementKeyboard goes with the announcement of a new event sent
+// to subscribers: registration and a link to the full event card.
+func EventAnnouncementKeyboard(event *internalModels.Event) *models.InlineKeyboardMarkup {
+	rows := [][]models.InlineKeyboardButton{
+		{{Text: "✅ Я прийду", CallbackData: fmt.Sprintf("rsvp:%d", event.ID)}},
+	}
+
+	if event.RegistrationURL != nil && *event.RegistrationURL != "" {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: "📝 Зареєструватися", URL: *event.RegistrationURL},
+		})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "ℹ️ Детальніше", CallbackData: fmt.Sprintf("event:%d", event.ID)},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// PastEventsKeyboard moves between the months of the past events view.
+// prev and next are the nearest months with events, zero at either end.
+func PastEventsKeyboard(prev, next time.Time) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+
+	if nav := archiveNavigationRow("past_events", prev, next); len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "📋 Усі анонси", CallbackData: "events"},
+		{Text: messages.NavigationButtons["back_to_start"], CallbackData: "back_to_start"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// archiveNavigationRow links to the previous and next month of a past
+// events view as "<prefix>:<YYYY-MM>".
+func archiveNavigationRow(prefix string, prev, next time.Time) []models.InlineKeyboardButton {
+	var row []models.InlineKeyboardButton
+
+	if !prev.IsZero() {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         fmt.Sprintf("◀️ %s %d", messages.MonthName(prev.Month()), prev.Year()),
+			CallbackData: prefix + ":" + prev.Format("2006-01"),
+		})
+	}
+	if !next.IsZero() {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         fmt.Sprintf("%s %d ▶️", messages.MonthName(next.Month()), next.Year()),
+			CallbackData: prefix + ":" + next.Format("2006-01"),
+		})
+	}
+
+	return row
+}
+
+func BackToEventsKeyboard() *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: messages.NavigationButtons["back"], CallbackData: "events"},
+				{Text: messages.NavigationButtons["back_to_start"], CallbackData: "back_to_start"},
+			},
+		},
+	}
+}
+
+// CalendarKeyboard switches between the calendar views. The week view also
+// gets buttons to move to the previous or next week.
+func CalendarKeyboard(view string, weekOffset int) *models.InlineKeyboardMarkup {
+	rows := [][]models.InlineKeyboardButton{
+		{
+			{Text: "Сьогодні", CallbackData: "calendar_today"},
+			{Text: "Цей тиждень", CallbackData: "calendar_week:0"},
+			{Text: "Цей місяць", CallbackData: "calendar_month"},
+		},
+	}
+
+	if view == "calendar_week" {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{Text: "◀️ Попередній", CallbackData: fmt.Sprintf("calendar_week:%d", weekOffset-1)},
+			{Text: "Наступний ▶️", CallbackData: fmt.Sprintf("calendar_week:%d", weekOffset+1)},
+		})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{Text: "📋 Усі анонси", CallbackData: "events"},
+		{Text: "📚 Минулі події", CallbackData: "past_events"},
+	}, []models.InlineKeyboardButton{
+		{Text: messages.NavigationButtons["back_to_start"], CallbackData: "back_to_start"},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
 func MainMenuReplyKeyboard(isActive bool) *models.ReplyKeyboardMarkup {
 	var buttons [][]models.KeyboardButton
 
@@ -0,0 +1,104 @@
+package messages
+
+import (
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+// FormatEventDate renders when the event takes place in Warsaw time, e.g.
+// "25.12.2025", "25.12.2025 16:00–18:00" or "15.07.2026 – 17.07.2026" for
+// a camp.
+func FormatEventDate(event *models.Event) string {
+	const (
+		dateLayout     = "02.01.2006"
+		dateTimeLayout = "02.01.2006 15:04"
+	)
+
+	start := clock.In(event.Date)
+	var end time.Time
+	if event.EndDate != nil {
+		end = clock.In(*event.EndDate)
+	}
+
+	if event.AllDay {
+		if !event.IsMultiDay() {
+			return start.Format(dateLayout)
+		}
+		return start.Format(dateLayout) + " – " + end.Format(dateLayout)
+	}
+
+	switch {
+	case event.EndDate == nil:
+		return start.Format(dateTimeLayout)
+	case event.IsMultiDay():
+		return start.Format(dateTimeLayout) + " – " + end.Format(dateTimeLayout)
+	default:
+		return start.Format(dateTimeLayout) + "–" + end.Format("15:04")
+	}
+}
+
+var monthNames = []string{
+	"Січень", "Лютий", "Березень", "Квітень", "Травень", "Червень",
+	"Липень", "Серпень", "Вересень", "Жовтень", "Листопад", "Грудень",
+}
+
+// MonthName returns the Ukrainian name of the month, e.g. "Жовтень".
+func MonthName(month time.Month) string {
+	return monthNames[month-1]
+}
+
+// FormatEventDetails renders the body of an event entry as shown to users:
+// date, description, location, category and registration link. The fields
+// are escaped for HTML parse mode.
+func FormatEventDetails(event *models.Event) string {
+	text := fmt.Sprintf("📅 %s\n", FormatEventDate(event)) +
+		fmt.Sprintf("📝 %s\n", html.EscapeString(event.Description))
+
+	if event.Location != nil && *event.Location != "" {
+		text += fmt.Sprintf("📍 %s\n", html.EscapeString(*event.Location))
+	}
+
+	if event.Category != nil && *event.Category != "" {
+		text += fmt.Sprintf("🏷 %s\n", html.EscapeString(*event.Category))
+	}
+
+	if event.RegistrationURL != nil && *event.RegistrationURL != "" {
+		text += fmt.Sprintf("🔗 <a href=\"%s\">Реєстрація</a>\n", html.EscapeString(*event.RegistrationURL))
+	}
+
+	if event.Capacity > 0 {
+		text += fmt.Sprintf("👥 Місць: %d\n", event.Capacity)
+	}
+
+	return text
+}
+
+// FormatDuration renders a whole number of minutes as "1 день", "2 години" etc.,
+// using the largest unit that divides it evenly.
+func FormatDuration(minutes int) string {
+	switch {
+	case minutes == 0:
+		return Plural(minutes, "хвилину", "хвилини", "хвилин")
+	case minutes%(7*24*60) == 0:
+		return Plural(minutes/(7*24*60), "тиждень", "тижні", "тижнів")
+	case minutes%(24*60) == 0:
+		return Plural(minutes/(24*60), "день", "дні", "днів")
+	case minutes%60 == 0:
+		return Plural(minutes/60, "годину", "години", "годин")
+	default:
+		return Plural(minutes, "хвилину", "хвилини", "хвилин")
+	}
+}
+
+// FormatReminderOffset renders a reminder offset such as "за 1 день"; zero
+// means a reminder on the day of the event.
+func FormatReminderOffset(minutes int) string {
+	if minutes == 0 {
+		return "у день події"
+	}
+	return "за " + FormatDuration(minutes)
+}
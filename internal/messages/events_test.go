@@ -0,0 +1,106 @@
+package messages
+
+import (
+	"testing"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+// Event times come from the database in UTC and are shown in Warsaw time,
+// CET (UTC+1) in winter and CEST (UTC+2) in summer.
+func TestFormatEventDateAcrossDST(t *testing.T) {
+	utc := func(value string) time.Time {
+		t.Helper()
+		parsed, err := time.Parse("2006-01-02 15:04", value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name  string
+		event models.Event
+		want  string
+	}{
+		{
+			name:  "day before summer time",
+			event: models.Event{Date: utc("2026-03-28 15:00"), EndDate: ptr(utc("2026-03-28 17:00"))},
+			want:  "28.03.2026 16:00–18:00",
+		},
+		{
+			name:  "first day of summer time",
+			event: models.Event{Date: utc("2026-03-29 14:00"), EndDate: ptr(utc("2026-03-29 16:00"))},
+			want:  "29.03.2026 16:00–18:00",
+		},
+		{
+			name:  "first day of winter time",
+			event: models.Event{Date: utc("2026-10-25 15:00")},
+			want:  "25.10.2026 16:00",
+		},
+		{
+			name:  "overnight across the switch to winter time",
+			event: models.Event{Date: utc("2026-10-24 20:00"), EndDate: ptr(utc("2026-10-25 09:00"))},
+			want:  "24.10.2026 22:00 – 25.10.2026 10:00",
+		},
+		{
+			name:  "all-day event starts at Warsaw midnight",
+			event: models.Event{Date: utc("2026-07-14 22:00"), AllDay: true},
+			want:  "15.07.2026",
+		},
+		{
+			name:  "all-day event spanning the switch to winter time",
+			event: models.Event{Date: utc("2026-10-23 22:00"), EndDate: ptr(utc("2026-10-25 23:00")), AllDay: true},
+			want:  "24.10.2026 – 26.10.2026",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatEventDate(&tt.event); got != tt.want {
+				t.Errorf("FormatEventDate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Imported events may contain characters that break HTML parse mode.
+func TestFormatEventDetailsEscapes(t *testing.T) {
+	location := "Зал <A&B>"
+	link := "https://example.com/?a=1&b=\"2\""
+	event := models.Event{
+		Date:            time.Date(2026, time.November, 1, 17, 0, 0, 0, time.UTC),
+		Description:     "Q&A для <Youth>",
+		Location:        &location,
+		RegistrationURL: &link,
+	}
+
+	want := "📅 01.11.2026 18:00\n" +
+		"📝 Q&amp;A для &lt;Youth&gt;\n" +
+		"📍 Зал &lt;A&amp;B&gt;\n" +
+		"🔗 <a href=\"https://example.com/?a=1&amp;b=&#34;2&#34;\">Реєстрація</a>\n"
+
+	if got := FormatEventDetails(&event); got != want {
+		t.Errorf("FormatEventDetails() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatReminderOffset(t *testing.T) {
+	tests := map[int]string{
+		0:           "у день події",
+		60:          "за 1 годину",
+		3 * 60:      "за 3 години",
+		24 * 60:     "за 1 день",
+		2 * 24 * 60: "за 2 дні",
+		5 * 24 * 60: "за 5 днів",
+		7 * 24 * 60: "за 1 тиждень",
+	}
+
+	for minutes, want := range tests {
+		if got := FormatReminderOffset(minutes); got != want {
+			t.Errorf("FormatReminderOffset(%d) = %q, want %q", minutes, got, want)
+		}
+	}
+}
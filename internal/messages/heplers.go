@@ -1,5 +1,19 @@
 package messages
 
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// CaptionLimit is the maximum length of a photo caption in Telegram.
+const CaptionLimit = 1024
+
+// FitsCaption reports whether text can be sent as a photo caption. HTML tags
+// are counted too, so the check errs on the safe side.
+func FitsCaption(text string) bool {
+	return utf8.RuneCountInString(text) <= CaptionLimit
+}
+
 func GetText(key string) string {
 	if text, ok := Texts[key]; ok {
 		return text
@@ -18,3 +32,15 @@ func HasText(key string) bool {
 	_, ok := Texts[key]
 	return ok
 }
+
+// Plural formats n with the Ukrainian noun form for 1, 2-4 and 5+.
+func Plural(n int, one, few, many string) string {
+	form := many
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		form = one
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		form = few
+	}
+	return fmt.Sprintf("%d %s", n, form)
+}
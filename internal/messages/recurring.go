@@ -0,0 +1,110 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/recurrence"
+)
+
+// Ordinals in masculine and feminine forms, matching the weekday's gender.
+var ordinalNames = map[int][2]string{
+	1:  {"перший", "перша"},
+	2:  {"другий", "друга"},
+	3:  {"третій", "третя"},
+	4:  {"четвертий", "четверта"},
+	5:  {"п'ятий", "п'ята"},
+	-1: {"останній", "остання"},
+	-2: {"передостанній", "передостання"},
+}
+
+func isFeminine(weekday time.Weekday) bool {
+	switch weekday {
+	case time.Wednesday, time.Friday, time.Saturday, time.Sunday:
+		return true
+	}
+	return false
+}
+
+// FormatSchedule describes when the series takes place,
+// e.g. "Щомісяця: перша субота о 16:00".
+func FormatSchedule(event *models.RecurringEvent) string {
+	rule, err := event.GetRule()
+	if err != nil {
+		return fmt.Sprintf("%s о %s (правило: %s)", event.GetDayName(), event.EventTime, event.RRule)
+	}
+
+	text := DescribeRule(rule) + " о " + event.EventTime
+	first, size := utf8.DecodeRuneInString(text)
+	return string(unicode.ToUpper(first)) + text[size:]
+}
+
+// DescribeRule returns a short Ukrainian description of the rule such as
+// "щомісяця: перша субота".
+func DescribeRule(r *recurrence.Rule) string {
+	var text string
+
+	switch r.Freq {
+	case recurrence.Daily:
+		text = "щодня"
+		if r.Interval > 1 {
+			text = "кожні " + Plural(r.Interval, "день", "дні", "днів")
+		}
+	case recurrence.Weekly:
+		text = "щотижня"
+		if r.Interval > 1 {
+			text = "кожні " + Plural(r.Interval, "тиждень", "тижні", "тижнів")
+		}
+	case recurrence.Monthly:
+		text = "щомісяця"
+		if r.Interval > 1 {
+			text = "кожні " + Plural(r.Interval, "місяць", "місяці", "місяців")
+		}
+	}
+
+	var details []string
+
+	for _, day := range r.ByDay {
+		name := strings.ToLower(models.WeekdayName(int(day.Weekday)))
+		if day.Ordinal != 0 {
+			forms, ok := ordinalNames[day.Ordinal]
+			if ok {
+				form := forms[0]
+				if isFeminine(day.Weekday) {
+					form = forms[1]
+				}
+				name = form + " " + name
+			} else {
+				name = fmt.Sprintf("%d-й %s", day.Ordinal, name)
+			}
+		}
+		details = append(details, name)
+	}
+
+	for _, monthDay := range r.ByMonthDay {
+		if monthDay == -1 {
+			details = append(details, "останній день місяця")
+		} else if monthDay < 0 {
+			details = append(details, fmt.Sprintf("%d-й день з кінця місяця", -monthDay))
+		} else {
+			details = append(details, fmt.Sprintf("%d числа", monthDay))
+		}
+	}
+
+	if len(details) > 0 {
+		text += ": " + strings.Join(details, ", ")
+	}
+
+	if r.Until != nil {
+		text += ", до " + r.Until.Format("02.01.2006")
+	}
+	if r.Count > 0 {
+		text += ", всього " + Plural(r.Count, "раз", "рази", "разів")
+	}
+
+	return text
+}
@@ -0,0 +1,49 @@
+package messages
+
+import (
+	"testing"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+func TestFormatSchedule(t *testing.T) {
+	tests := []struct {
+		name  string
+		event models.RecurringEvent
+		want  string
+	}{
+		{
+			name:  "weekly without rule",
+			event: models.RecurringEvent{DayOfWeek: 0, EventTime: "10:00"},
+			want:  "Щотижня: неділя о 10:00",
+		},
+		{
+			name:  "first saturday",
+			event: models.RecurringEvent{RRule: "FREQ=MONTHLY;BYDAY=1SA", EventTime: "16:00"},
+			want:  "Щомісяця: перша субота о 16:00",
+		},
+		{
+			name:  "last monday",
+			event: models.RecurringEvent{RRule: "FREQ=MONTHLY;BYDAY=-1MO", EventTime: "18:30"},
+			want:  "Щомісяця: останній понеділок о 18:30",
+		},
+		{
+			name:  "every two weeks, limited count",
+			event: models.RecurringEvent{RRule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,FR;COUNT=5", EventTime: "19:00"},
+			want:  "Кожні 2 тижні: вівторок, п'ятниця, всього 5 разів о 19:00",
+		},
+		{
+			name:  "invalid rule",
+			event: models.RecurringEvent{DayOfWeek: 3, RRule: "FREQ=YEARLY", EventTime: "12:00"},
+			want:  "Середа о 12:00 (правило: FREQ=YEARLY)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatSchedule(&tt.event); got != tt.want {
+				t.Errorf("FormatSchedule() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
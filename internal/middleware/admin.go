@@ -74,3 +74,8 @@ func AdminOnly(next bot.HandlerFunc) bot.HandlerFunc {
 		next(ctx, b, update)
 	}
 }
+
+// AdminIDs returns the configured admin user IDs.
+func AdminIDs() []int64 {
+	return append([]int64(nil), adminIDs...)
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Category is an event category managed by admins. Events refer to it by
+// name, so renaming a category also renames it on its events.
+type Category struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	Emoji     string    `db:"emoji"`
+	SortOrder int       `db:"sort_order"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Label is the category name with its emoji, as shown on buttons.
+func (c *Category) Label() string {
+	if c.Emoji == "" {
+		return c.Name
+	}
+	return c.Emoji + " " + c.Name
+}
@@ -1,10 +1,21 @@
 package models
 
 type ConversationState struct {
-	UserID        int64
-	State         string
-	EventData     *Event
-	BroadcastText string
+	UserID             int64
+	State              string
+	EventData          *Event
+	RecurringEventData *RecurringEvent
+	ExceptionData      *RecurringEventException
+	EditField          string
+	// ReturnToConfirm sends the add-event dialog back to its preview after a
+	// single field was changed from there.
+	ReturnToConfirm bool
+	BroadcastText   string
+	// ImportEvents holds the parsed events of a bulk import until the admin
+	// confirms it.
+	ImportEvents []*Event
+	// CategoryData is the category being added (ID 0) or changed.
+	CategoryData *Category
 }
 
 const (
@@ -15,9 +26,33 @@ const (
 	StateAwaitingLocation         = "awaiting_location"
 	StateAwaitingCategory         = "awaiting_category"
 	StateAwaitingRegURL           = "awaiting_registration_url"
+	StateAwaitingImage            = "awaiting_image"
 	StateAwaitingConfirm          = "awaiting_confirmation"
 	StateAwaitingDeleteID         = "awaiting_delete_id"
 	StateAwaitingDeleteConfirm    = "awaiting_delete_confirm"
 	StateAwaitingBroadcastText    = "awaiting_broadcast_text"
 	StateAwaitingBroadcastConfirm = "awaiting_broadcast_confirm"
+	StateAwaitingReminderEventID  = "awaiting_reminder_event_id"
+	StateAwaitingEditEventID      = "awaiting_edit_event_id"
+	StateAwaitingEventEditValue   = "awaiting_event_edit_value"
+	StateAwaitingEventEditConfirm = "awaiting_event_edit_confirm"
+	StateAwaitingPublishAt        = "awaiting_publish_at"
+	StateAwaitingImportFile       = "awaiting_import_file"
+	StateAwaitingImportConfirm    = "awaiting_import_confirm"
+	StateAwaitingCategoryName     = "awaiting_category_name"
+	StateAwaitingCategoryEmoji    = "awaiting_category_emoji"
+	StateAwaitingCloneEventID     = "awaiting_clone_event_id"
+	StateAwaitingCloneDate        = "awaiting_clone_date"
+
+	StateAwaitingRecurringTitle          = "awaiting_recurring_title"
+	StateAwaitingRecurringDesc           = "awaiting_recurring_description"
+	StateAwaitingRecurringDay            = "awaiting_recurring_day"
+	StateAwaitingRecurringTime           = "awaiting_recurring_time"
+	StateAwaitingRecurringReminderOffset = "awaiting_recurring_reminder_offset"
+	StateAwaitingRecurringReminderTime   = "awaiting_recurring_reminder_time"
+	StateAwaitingRecurringLocation       = "awaiting_recurring_location"
+	StateAwaitingRecurringCategory       = "awaiting_recurring_category"
+	StateAwaitingRecurringRegURL         = "awaiting_recurring_registration_url"
+	StateAwaitingRecurringEditValue      = "awaiting_recurring_edit_value"
+	StateAwaitingRecurringExceptionValue = "awaiting_recurring_exception_value"
 )
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+)
 
 type Event struct {
 	ID              int       `db:"id"`
@@ -10,7 +14,60 @@ type Event struct {
 	Location        *string   `db:"location"`
 	Category        *string   `db:"category"`
 	RegistrationURL *string   `db:"registration_url"`
-	IsPublished     bool      `db:"is_published"`
-	CreatedAt       time.Time `db:"created_at"`
-	CreatedBy       int64     `db:"created_by"`
+	// EndDate is when the event ends, if known. For all-day events it is the
+	// last day of the event.
+	EndDate *time.Time `db:"end_date"`
+	// AllDay events have a date but no time.
+	AllDay bool `db:"all_day"`
+	// Latitude and Longitude pin the location on the map, if known.
+	Latitude  *float64 `db:"latitude"`
+	Longitude *float64 `db:"longitude"`
+	// ImageFileID is the Telegram file_id of the event poster.
+	ImageFileID *string `db:"image_file_id"`
+	IsPublished bool    `db:"is_published"`
+	// PublishAt is when a draft becomes visible automatically, if scheduled.
+	PublishAt *time.Time `db:"publish_at"`
+	// ReportURL links to the photo report of a past event.
+	ReportURL *string `db:"report_url"`
+	// ArchivedAt is when the retention job archived the past event. Archived
+	// events are only shown to admins.
+	ArchivedAt *time.Time `db:"archived_at"`
+	// AnnouncedAt is when the event was announced to subscribers; an event
+	// is announced at most once.
+	AnnouncedAt *time.Time `db:"announced_at"`
+	// Capacity limits in-bot registrations; 0 means no limit.
+	Capacity  int       `db:"capacity"`
+	CreatedAt time.Time `db:"created_at"`
+	CreatedBy int64     `db:"created_by"`
+}
+
+// LastDay returns midnight of the last Warsaw day of the event.
+func (e *Event) LastDay() time.Time {
+	end := e.Date
+	if e.EndDate != nil {
+		end = *e.EndDate
+	}
+	return clock.StartOfDay(end)
+}
+
+// IsMultiDay reports whether the event ends on a later day than it starts.
+func (e *Event) IsMultiDay() bool {
+	return e.LastDay().After(clock.StartOfDay(e.Date))
+}
+
+// HasCoordinates reports whether the event location is pinned on the map.
+func (e *Event) HasCoordinates() bool {
+	return e.Latitude != nil && e.Longitude != nil
+}
+
+// IsScheduled reports whether the event is a draft waiting for its scheduled
+// publish time.
+func (e *Event) IsScheduled() bool {
+	return !e.IsPublished && e.PublishAt != nil
+}
+
+// IsVisible reports whether users can see the event: it is published and
+// has not been archived.
+func (e *Event) IsVisible() bool {
+	return e.IsPublished && e.ArchivedAt == nil
 }
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Statuses of an event registration.
+const (
+	RegistrationGoing    = "going"
+	RegistrationWaitlist = "waitlist"
+)
+
+// EventRegistration is a user's in-bot RSVP for a one-off event.
+// CheckedInAt is set when an organiser marks the user as arrived.
+type EventRegistration struct {
+	ID          int        `db:"id"`
+	EventID     int        `db:"event_id"`
+	UserID      int64      `db:"user_id"`
+	Status      string     `db:"status"`
+	CreatedAt   time.Time  `db:"created_at"`
+	CheckedInAt *time.Time `db:"checked_in_at"`
+}
+
+func (r *EventRegistration) IsCheckedIn() bool {
+	return r.CheckedInAt != nil
+}
+
+// Attendee is a registration together with the user's Telegram names.
+type Attendee struct {
+	EventRegistration
+	FirstName string `db:"first_name"`
+	Username  string `db:"username"`
+}
+
+// DisplayName returns the user's first name, falling back to the Telegram ID
+// for users the bot no longer knows.
+func (a *Attendee) DisplayName() string {
+	if a.FirstName != "" {
+		return a.FirstName
+	}
+	return fmt.Sprintf("ID %d", a.UserID)
+}
+
+// RegistrationCounts sums up the registrations of an event.
+type RegistrationCounts struct {
+	Going     int
+	Waitlist  int
+	CheckedIn int
+}
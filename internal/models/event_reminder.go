@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// EventReminder schedules a reminder OffsetMinutes before a one-off event starts.
+type EventReminder struct {
+	ID            int `db:"id"`
+	EventID       int `db:"event_id"`
+	OffsetMinutes int `db:"offset_minutes"`
+}
+
+func (r *EventReminder) Offset() time.Duration {
+	return time.Duration(r.OffsetMinutes) * time.Minute
+}
+
+// ReminderOffsetPresets are the offsets admins can toggle for an event, in minutes.
+var ReminderOffsetPresets = []int{7 * 24 * 60, 3 * 24 * 60, 24 * 60, 3 * 60, 2 * 60, 60}
+
+// Statuses of a reminder_log entry.
+const (
+	ReminderStatusSent    = "sent"
+	ReminderStatusSkipped = "skipped"
+)
@@ -1,6 +1,19 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/recurrence"
+)
+
+const StartDateLayout = "2006-01-02"
+
+const (
+	HolidayPolicyRun  = "run"
+	HolidayPolicySkip = "skip"
+)
 
 type RecurringEvent struct {
 	ID          int    `db:"id" json:"id"`
@@ -10,6 +23,13 @@ type RecurringEvent struct {
 	DayOfWeek int    `db:"day_of_week" json:"day_of_week"`
 	EventTime string `db:"event_time" json:"event_time"`
 
+	// RRule is an optional RFC 5545 recurrence rule, e.g. "FREQ=MONTHLY;BYDAY=1SA".
+	// An empty rule means "every week on DayOfWeek".
+	RRule string `db:"rrule" json:"rrule"`
+	// StartDate (YYYY-MM-DD) anchors the rule for INTERVAL and COUNT.
+	// When empty, the creation date is used.
+	StartDate string `db:"start_date" json:"start_date"`
+
 	ReminderDayOffset int    `db:"reminder_day_offset" json:"reminder_day_offset"`
 	ReminderTime      string `db:"reminder_time" json:"reminder_time"`
 
@@ -17,6 +37,10 @@ type RecurringEvent struct {
 	Category        string `db:"category" json:"category"`
 	RegistrationURL string `db:"registration_url" json:"registration_url"`
 
+	// HolidayPolicy decides what happens to occurrences falling on a Polish
+	// public holiday. An empty value means HolidayPolicyRun.
+	HolidayPolicy string `db:"holiday_policy" json:"holiday_policy"`
+
 	IsActive  bool      `db:"is_active" json:"is_active"`
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
 	CreatedBy int64     `db:"created_by" json:"created_by"`
@@ -24,19 +48,84 @@ type RecurringEvent struct {
 
 // Helper functions
 
+var dayNames = []string{"Неділя", "Понеділок", "Вівторок", "Середа", "Четвер", "П'ятниця", "Субота"}
+
+// WeekdayName returns the Ukrainian name of a time.Weekday-style day number.
+func WeekdayName(day int) string {
+	day = day % 7
+	if day < 0 {
+		day += 7
+	}
+	return dayNames[day]
+}
+
+// SkipsHolidays reports whether occurrences on public holidays are dropped.
+func (e *RecurringEvent) SkipsHolidays() bool {
+	return e.HolidayPolicy == HolidayPolicySkip
+}
+
 func (e *RecurringEvent) GetDayName() string {
-	days := []string{"Неділя", "Понеділок", "Вівторок", "Середа", "Четвер", "П'ятниця", "Субота"}
-	return days[e.DayOfWeek]
+	return WeekdayName(e.DayOfWeek)
 }
 
 func (e *RecurringEvent) GetReminderDayName() string {
-	reminderDay := e.DayOfWeek + e.ReminderDayOffset
-	if reminderDay < 0 {
-		reminderDay += 7
+	return WeekdayName(e.DayOfWeek + e.ReminderDayOffset)
+}
+
+// GetRule returns the parsed recurrence rule of the series.
+func (e *RecurringEvent) GetRule() (*recurrence.Rule, error) {
+	if e.RRule == "" {
+		return recurrence.WeeklyOn(time.Weekday(e.DayOfWeek)), nil
+	}
+	return recurrence.Parse(e.RRule)
+}
+
+// SetRule sets the recurrence rule of the series; nil means every week on
+// DayOfWeek. DayOfWeek follows the first BYDAY weekday of the rule and is
+// cleared for rules without one, where it only orders the list.
+func (e *RecurringEvent) SetRule(rule *recurrence.Rule) {
+	if rule == nil {
+		e.RRule = ""
+		return
+	}
+
+	e.RRule = rule.String()
+	e.DayOfWeek = 0
+	if len(rule.ByDay) > 0 {
+		e.DayOfWeek = int(rule.ByDay[0].Weekday)
+	}
+}
+
+// Occurrences expands the series into start times within [from, to].
+func (e *RecurringEvent) Occurrences(from, to time.Time, location *time.Location) ([]time.Time, error) {
+	rule, err := e.GetRule()
+	if err != nil {
+		return nil, fmt.Errorf("recurring event %d: %w", e.ID, err)
 	}
 
-	reminderDay = reminderDay % 7
+	hour, minute, err := ParseClockTime(e.EventTime)
+	if err != nil {
+		return nil, fmt.Errorf("recurring event %d: %w", e.ID, err)
+	}
+
+	startDay := e.CreatedAt.In(location)
+	if e.StartDate != "" {
+		startDay, err = time.ParseInLocation(StartDateLayout, strings.TrimSpace(e.StartDate), location)
+		if err != nil {
+			return nil, fmt.Errorf("recurring event %d: invalid start date %q", e.ID, e.StartDate)
+		}
+	}
+
+	dtstart := time.Date(startDay.Year(), startDay.Month(), startDay.Day(), hour, minute, 0, 0, location)
 
-	days := []string{"Неділя", "Понеділок", "Вівторок", "Середа", "Четвер", "П'ятниця", "Субота"}
-	return days[reminderDay]
+	return rule.Between(dtstart, from, to), nil
+}
+
+// ParseClockTime parses a "HH:MM" string as used by EventTime and ReminderTime.
+func ParseClockTime(value string) (hour int, minute int, err error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return t.Hour(), t.Minute(), nil
 }
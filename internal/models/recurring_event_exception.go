@@ -0,0 +1,128 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/holidays"
+)
+
+// RecurringEventException cancels or changes a single occurrence of a series.
+// OccurrenceDate is the scheduled date (YYYY-MM-DD) of the affected occurrence;
+// empty override fields keep the series values.
+type RecurringEventException struct {
+	ID               int       `db:"id" json:"id"`
+	RecurringEventID int       `db:"recurring_event_id" json:"recurring_event_id"`
+	OccurrenceDate   string    `db:"occurrence_date" json:"occurrence_date"`
+	IsCancelled      bool      `db:"is_cancelled" json:"is_cancelled"`
+	OverrideDate     string    `db:"override_date" json:"override_date"`
+	OverrideTime     string    `db:"override_time" json:"override_time"`
+	OverrideLocation string    `db:"override_location" json:"override_location"`
+	CreatedAt        time.Time `db:"created_at" json:"created_at"`
+	CreatedBy        int64     `db:"created_by" json:"created_by"`
+}
+
+// Occurrence is a single resolved date of a recurring event.
+type Occurrence struct {
+	Event *RecurringEvent
+	// ScheduledDate is the date (YYYY-MM-DD) the rule produced. It identifies
+	// the occurrence even after it was moved.
+	ScheduledDate string
+	Start         time.Time
+	Location      string
+	IsCancelled   bool
+	IsChanged     bool
+	// Holiday names the public holiday the occurrence was skipped for, if
+	// the series skips holidays.
+	Holiday string
+}
+
+// exceptionSlack is how far an occurrence may be moved by an exception and
+// still be picked up by ResolvedOccurrences.
+const exceptionSlack = 31 * 24 * time.Hour
+
+// ResolvedOccurrences expands the series within [from, to] and applies the
+// given exceptions and the holiday policy. Cancelled occurrences are kept and flagged so callers can
+// decide whether to show them.
+func (e *RecurringEvent) ResolvedOccurrences(from, to time.Time, location *time.Location, exceptions []RecurringEventException) ([]Occurrence, error) {
+	starts, err := e.Occurrences(from.Add(-exceptionSlack), to.Add(exceptionSlack), location)
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string]RecurringEventException, len(exceptions))
+	for _, exception := range exceptions {
+		if exception.RecurringEventID == e.ID {
+			byDate[exception.OccurrenceDate] = exception
+		}
+	}
+
+	var result []Occurrence
+
+	for _, start := range starts {
+		occurrence := Occurrence{
+			Event:         e,
+			ScheduledDate: start.Format(StartDateLayout),
+			Start:         start,
+			Location:      e.Location,
+		}
+
+		if exception, ok := byDate[occurrence.ScheduledDate]; ok {
+			if err := exception.apply(&occurrence, location); err != nil {
+				return nil, fmt.Errorf("recurring event %d: %w", e.ID, err)
+			}
+		} else if e.SkipsHolidays() {
+			// An explicit exception wins over the holiday policy, so admins
+			// can still hold a single occurrence on a holiday.
+			if holiday, ok := holidays.Lookup(start, location); ok {
+				occurrence.IsCancelled = true
+				occurrence.Holiday = holiday.Title()
+			}
+		}
+
+		if occurrence.Start.Before(from) || occurrence.Start.After(to) {
+			continue
+		}
+		result = append(result, occurrence)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Start.Before(result[j].Start) })
+
+	return result, nil
+}
+
+func (x *RecurringEventException) apply(occurrence *Occurrence, location *time.Location) error {
+	occurrence.IsChanged = true
+
+	if x.IsCancelled {
+		occurrence.IsCancelled = true
+		return nil
+	}
+
+	start := occurrence.Start
+
+	if x.OverrideDate != "" {
+		day, err := time.ParseInLocation(StartDateLayout, x.OverrideDate, location)
+		if err != nil {
+			return fmt.Errorf("invalid override date %q", x.OverrideDate)
+		}
+		start = time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, location)
+	}
+
+	if x.OverrideTime != "" {
+		hour, minute, err := ParseClockTime(x.OverrideTime)
+		if err != nil {
+			return err
+		}
+		start = time.Date(start.Year(), start.Month(), start.Day(), hour, minute, 0, 0, location)
+	}
+
+	occurrence.Start = start
+
+	if x.OverrideLocation != "" {
+		occurrence.Location = x.OverrideLocation
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+)
+
+// sundayService runs every Sunday at 10:00. In November 2026 the Sundays
+// are 1 (All Saints' Day, a public holiday), 8, 15, 22 and 29.
+func sundayService(policy string) *RecurringEvent {
+	return &RecurringEvent{
+		ID:            1,
+		Title:         "Недільне служіння",
+		DayOfWeek:     int(time.Sunday),
+		EventTime:     "10:00",
+		StartDate:     "2026-01-04",
+		Location:      "Зал",
+		HolidayPolicy: policy,
+	}
+}
+
+func TestResolvedOccurrences(t *testing.T) {
+	from := clock.Date(2026, time.November, 1, 0, 0)
+	to := clock.Date(2026, time.November, 30, 23, 59)
+
+	tests := []struct {
+		name       string
+		policy     string
+		exceptions []RecurringEventException
+		want       []string
+	}{
+		{
+			name:   "run policy keeps holidays",
+			policy: HolidayPolicyRun,
+			want: []string{
+				"2026-11-01 01.11 10:00 Зал", "2026-11-08 08.11 10:00 Зал", "2026-11-15 15.11 10:00 Зал",
+				"2026-11-22 22.11 10:00 Зал", "2026-11-29 29.11 10:00 Зал",
+			},
+		},
+		{
+			name:   "skip policy cancels holidays",
+			policy: HolidayPolicySkip,
+			want: []string{
+				"2026-11-01 01.11 10:00 Зал cancelled holiday=День всіх святих (Wszystkich Świętych)", "2026-11-08 08.11 10:00 Зал",
+				"2026-11-15 15.11 10:00 Зал", "2026-11-22 22.11 10:00 Зал", "2026-11-29 29.11 10:00 Зал",
+			},
+		},
+		{
+			name:   "cancelled occurrence on a holiday",
+			policy: HolidayPolicySkip,
+			exceptions: []RecurringEventException{
+				{RecurringEventID: 1, OccurrenceDate: "2026-11-01", IsCancelled: true},
+			},
+			want: []string{
+				"2026-11-01 01.11 10:00 Зал cancelled changed", "2026-11-08 08.11 10:00 Зал",
+				"2026-11-15 15.11 10:00 Зал", "2026-11-22 22.11 10:00 Зал", "2026-11-29 29.11 10:00 Зал",
+			},
+		},
+		{
+			name:   "exception overrides the skip policy",
+			policy: HolidayPolicySkip,
+			exceptions: []RecurringEventException{
+				{RecurringEventID: 1, OccurrenceDate: "2026-11-01", OverrideTime: "18:00"},
+				// Moved onto Independence Day, still held.
+				{RecurringEventID: 1, OccurrenceDate: "2026-11-08", OverrideDate: "2026-11-11", OverrideLocation: "Парк"},
+			},
+			want: []string{
+				"2026-11-01 01.11 18:00 Зал changed", "2026-11-08 11.11 10:00 Парк changed",
+				"2026-11-15 15.11 10:00 Зал", "2026-11-22 22.11 10:00 Зал", "2026-11-29 29.11 10:00 Зал",
+			},
+		},
+		{
+			name:   "occurrences moved across the window edges",
+			policy: HolidayPolicyRun,
+			exceptions: []RecurringEventException{
+				// 25 October moved into the window, after 1 November.
+				{RecurringEventID: 1, OccurrenceDate: "2026-10-25", OverrideDate: "2026-11-03"},
+				// 29 November moved out of it.
+				{RecurringEventID: 1, OccurrenceDate: "2026-11-29", OverrideDate: "2026-12-02"},
+				// 6 December moved back into it.
+				{RecurringEventID: 1, OccurrenceDate: "2026-12-06", OverrideDate: "2026-11-30", OverrideTime: "19:00"},
+				// Other series are ignored.
+				{RecurringEventID: 2, OccurrenceDate: "2026-11-15", IsCancelled: true},
+			},
+			want: []string{
+				"2026-11-01 01.11 10:00 Зал", "2026-10-25 03.11 10:00 Зал changed", "2026-11-08 08.11 10:00 Зал",
+				"2026-11-15 15.11 10:00 Зал", "2026-11-22 22.11 10:00 Зал", "2026-12-06 30.11 19:00 Зал changed",
+			},
+		},
+		{
+			name:   "moves beyond the slack are not picked up",
+			policy: HolidayPolicyRun,
+			exceptions: []RecurringEventException{
+				// 27 September is 35 days before the window.
+				{RecurringEventID: 1, OccurrenceDate: "2026-09-27", OverrideDate: "2026-11-04"},
+			},
+			want: []string{
+				"2026-11-01 01.11 10:00 Зал", "2026-11-08 08.11 10:00 Зал", "2026-11-15 15.11 10:00 Зал",
+				"2026-11-22 22.11 10:00 Зал", "2026-11-29 29.11 10:00 Зал",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			occurrences, err := sundayService(tt.policy).ResolvedOccurrences(from, to, clock.Location(), tt.exceptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, occurrence := range occurrences {
+				got = append(got, describeOccurrence(occurrence))
+			}
+
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("occurrences:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestResolvedOccurrencesInvalidException(t *testing.T) {
+	exceptions := []RecurringEventException{
+		{RecurringEventID: 1, OccurrenceDate: "2026-11-08", OverrideTime: "25:00"},
+	}
+
+	from := clock.Date(2026, time.November, 1, 0, 0)
+	to := clock.Date(2026, time.November, 30, 23, 59)
+	if _, err := sundayService(HolidayPolicyRun).ResolvedOccurrences(from, to, clock.Location(), exceptions); err == nil {
+		t.Error("ResolvedOccurrences() with an invalid override time succeeded")
+	}
+}
+
+// describeOccurrence renders the scheduled date, the actual start, the
+// location and the flags of the occurrence.
+func describeOccurrence(occurrence Occurrence) string {
+	text := occurrence.ScheduledDate + " " + occurrence.Start.Format("02.01 15:04") + " " + occurrence.Location
+	if occurrence.IsCancelled {
+		text += " cancelled"
+	}
+	if occurrence.IsChanged {
+		text += " changed"
+	}
+	if occurrence.Holiday != "" {
+		text += " holiday=" + occurrence.Holiday
+	}
+	return text
+}
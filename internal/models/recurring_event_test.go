@@ -0,0 +1,45 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/recurrence"
+)
+
+func TestSetRule(t *testing.T) {
+	tests := []struct {
+		rule      string
+		wantRRule string
+		wantDay   int
+	}{
+		{"FREQ=MONTHLY;BYDAY=1SA", "FREQ=MONTHLY;BYDAY=1SA", int(time.Saturday)},
+		{"freq=weekly;interval=2;byday=fr,mo", "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR,MO", int(time.Friday)},
+		{"FREQ=MONTHLY;BYMONTHDAY=15", "FREQ=MONTHLY;BYMONTHDAY=15", 0},
+		{"FREQ=DAILY", "FREQ=DAILY", 0},
+	}
+
+	for _, tt := range tests {
+		rule, err := recurrence.Parse(tt.rule)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.rule, err)
+		}
+
+		event := &RecurringEvent{DayOfWeek: int(time.Wednesday)}
+		event.SetRule(rule)
+
+		if event.RRule != tt.wantRRule || event.DayOfWeek != tt.wantDay {
+			t.Errorf("SetRule(%q) gives RRule %q and DayOfWeek %d, want %q and %d",
+				tt.rule, event.RRule, event.DayOfWeek, tt.wantRRule, tt.wantDay)
+		}
+	}
+}
+
+func TestSetRuleNilKeepsDay(t *testing.T) {
+	event := &RecurringEvent{DayOfWeek: int(time.Thursday), RRule: "FREQ=MONTHLY;BYDAY=-1TH"}
+	event.SetRule(nil)
+
+	if event.RRule != "" || event.DayOfWeek != int(time.Thursday) {
+		t.Errorf("SetRule(nil) gives RRule %q and DayOfWeek %d", event.RRule, event.DayOfWeek)
+	}
+}
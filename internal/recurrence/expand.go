@@ -0,0 +1,236 @@
+package recurrence
+
+import (
+	"log"
+	"sort"
+	"time"
+)
+
+// maxPeriods bounds the expansion loop so a malformed rule can never spin
+// forever. It covers more than 13 years of daily periods after from.
+const maxPeriods = 5000
+
+// Between returns the occurrences of the rule anchored at dtstart that fall
+// within [from, to]. Every occurrence keeps the wall-clock time and location
+// of dtstart, so DST changes do not move the start time.
+func (r *Rule) Between(dtstart, from, to time.Time) []time.Time {
+	location := dtstart.Location()
+	startDay := dateOf(dtstart)
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	var result []time.Time
+	count := 0
+
+	// COUNT is counted from dtstart, so only rules without it may skip the
+	// periods before from.
+	first := 0
+	if r.Count == 0 {
+		first = r.periodsBefore(startDay, from) / interval
+	}
+
+	for period := first; ; period++ {
+		if period-first == maxPeriods {
+			log.Printf("Error expanding recurrence %s: stopped after %d periods", r, maxPeriods)
+			break
+		}
+
+		periodStart := r.periodStart(startDay, period*interval)
+		if periodStart.After(to) {
+			break
+		}
+
+		for _, day := range r.periodDays(startDay, periodStart) {
+			occurrence := time.Date(day.Year(), day.Month(), day.Day(), dtstart.Hour(), dtstart.Minute(), 0, 0, location)
+			if occurrence.Before(dtstart) {
+				continue
+			}
+			if r.afterUntil(occurrence) {
+				return result
+			}
+
+			count++
+			if r.Count > 0 && count > r.Count {
+				return result
+			}
+
+			if occurrence.After(to) {
+				return result
+			}
+			if !occurrence.Before(from) {
+				result = append(result, occurrence)
+			}
+		}
+	}
+
+	return result
+}
+
+func (r *Rule) afterUntil(occurrence time.Time) bool {
+	if r.Until == nil {
+		return false
+	}
+	if r.untilIsDate {
+		return dateOf(occurrence).After(time.Date(r.Until.Year(), r.Until.Month(), r.Until.Day(), 0, 0, 0, 0, occurrence.Location()))
+	}
+	return occurrence.After(*r.Until)
+}
+
+// periodsBefore returns how many whole periods lie between the one
+// containing startDay and the one containing from.
+func (r *Rule) periodsBefore(startDay, from time.Time) int {
+	fromDay := dateOf(from.In(startDay.Location()))
+	if !fromDay.After(startDay) {
+		return 0
+	}
+
+	switch r.Freq {
+	case Daily:
+		return daysBetween(startDay, fromDay)
+	case Monthly:
+		return (fromDay.Year()-startDay.Year())*12 + int(fromDay.Month()-startDay.Month())
+	default:
+		return daysBetween(r.periodStart(startDay, 0), fromDay) / 7
+	}
+}
+
+// periodStart returns the first day of the period that is offset periods
+// after the one containing startDay.
+func (r *Rule) periodStart(startDay time.Time, offset int) time.Time {
+	switch r.Freq {
+	case Daily:
+		return startDay.AddDate(0, 0, offset)
+	case Monthly:
+		first := time.Date(startDay.Year(), startDay.Month(), 1, 0, 0, 0, 0, startDay.Location())
+		return first.AddDate(0, offset, 0)
+	default:
+		monday := startDay.AddDate(0, 0, -daysSinceMonday(startDay.Weekday()))
+		return monday.AddDate(0, 0, 7*offset)
+	}
+}
+
+// periodDays returns the sorted candidate days of a single period.
+func (r *Rule) periodDays(startDay, periodStart time.Time) []time.Time {
+	var days []time.Time
+
+	switch r.Freq {
+	case Daily:
+		days = []time.Time{periodStart}
+
+	case Weekly:
+		weekdays := []time.Weekday{startDay.Weekday()}
+		if len(r.ByDay) > 0 {
+			weekdays = weekdays[:0]
+			for _, day := range r.ByDay {
+				weekdays = append(weekdays, day.Weekday)
+			}
+		}
+		for _, weekday := range weekdays {
+			days = append(days, periodStart.AddDate(0, 0, daysSinceMonday(weekday)))
+		}
+
+	case Monthly:
+		monthLength := daysIn(periodStart)
+		switch {
+		case len(r.ByMonthDay) > 0:
+			for _, monthDay := range r.ByMonthDay {
+				if monthDay < 0 {
+					monthDay = monthLength + monthDay + 1
+				}
+				if monthDay >= 1 && monthDay <= monthLength {
+					days = append(days, periodStart.AddDate(0, 0, monthDay-1))
+				}
+			}
+		case len(r.ByDay) > 0:
+			for i := 0; i < monthLength; i++ {
+				days = append(days, periodStart.AddDate(0, 0, i))
+			}
+		default:
+			if startDay.Day() <= monthLength {
+				days = append(days, periodStart.AddDate(0, 0, startDay.Day()-1))
+			}
+		}
+	}
+
+	filtered := days[:0]
+	for _, day := range days {
+		if r.matchesByDay(day) && r.matchesByMonthDay(day) {
+			filtered = append(filtered, day)
+		}
+	}
+
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].Before(filtered[j]) })
+
+	unique := filtered[:0]
+	for i, day := range filtered {
+		if i == 0 || !day.Equal(filtered[i-1]) {
+			unique = append(unique, day)
+		}
+	}
+
+	return unique
+}
+
+func (r *Rule) matchesByDay(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+
+	for _, byDay := range r.ByDay {
+		if day.Weekday() != byDay.Weekday {
+			continue
+		}
+		if byDay.Ordinal == 0 {
+			return true
+		}
+
+		if byDay.Ordinal > 0 && (day.Day()-1)/7+1 == byDay.Ordinal {
+			return true
+		}
+		if byDay.Ordinal < 0 && -((daysIn(day)-day.Day())/7+1) == byDay.Ordinal {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Rule) matchesByMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+
+	for _, monthDay := range r.ByMonthDay {
+		if monthDay < 0 {
+			monthDay = daysIn(day) + monthDay + 1
+		}
+		if day.Day() == monthDay {
+			return true
+		}
+	}
+
+	return false
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+}
+
+// daysBetween counts calendar days, which are not all 24 hours long around
+// DST changes.
+func daysBetween(from, to time.Time) int {
+	utc := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return int(utc(to).Sub(utc(from)).Hours() / 24)
+}
+
+func daysSinceMonday(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
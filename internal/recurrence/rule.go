@@ -0,0 +1,237 @@
+package recurrence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rule is the subset of an RFC 5545 RRULE supported by the bot:
+// FREQ (DAILY, WEEKLY, MONTHLY), INTERVAL, BYDAY with an optional ordinal,
+// BYMONTHDAY, UNTIL and COUNT.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	Until      *time.Time
+	Count      int
+
+	// untilIsDate is set when UNTIL was given as a plain date, which is then
+	// compared against the occurrence's local calendar date.
+	untilIsDate bool
+}
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// WeekdayNum is a BYDAY entry. Ordinal is 0 for "every such weekday",
+// 1..5 for the n-th and -1..-5 for the n-th from the end of the month.
+type WeekdayNum struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// WeeklyOn returns the rule used by series without an explicit RRULE.
+func WeeklyOn(day time.Weekday) *Rule {
+	return &Rule{
+		Freq:     Weekly,
+		Interval: 1,
+		ByDay:    []WeekdayNum{{Weekday: day}},
+	}
+}
+
+// Parse parses an RRULE value such as "FREQ=MONTHLY;BYDAY=1SA". A leading
+// "RRULE:" prefix is accepted.
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(strings.ToUpper(value), "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("empty recurrence rule")
+	}
+
+	rule := &Rule{Interval: 1}
+
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+
+		name, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+
+		switch name {
+		case "FREQ":
+			switch Frequency(val) {
+			case Daily, Weekly, Monthly:
+				rule.Freq = Frequency(val)
+			default:
+				return nil, fmt.Errorf("unsupported frequency %q", val)
+			}
+
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("invalid interval %q", val)
+			}
+			rule.Interval = interval
+
+		case "BYDAY":
+			for _, item := range strings.Split(val, ",") {
+				day, err := parseWeekdayNum(item)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+
+		case "BYMONTHDAY":
+			for _, item := range strings.Split(val, ",") {
+				day, err := strconv.Atoi(item)
+				if err != nil || day == 0 || day < -31 || day > 31 {
+					return nil, fmt.Errorf("invalid month day %q", item)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, day)
+			}
+
+		case "UNTIL":
+			until, isDate, err := parseUntil(val)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+			rule.untilIsDate = isDate
+
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("invalid count %q", val)
+			}
+			rule.Count = count
+
+		default:
+			return nil, fmt.Errorf("unsupported rule part %q", name)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("FREQ is required")
+	}
+	if rule.Until != nil && rule.Count > 0 {
+		return nil, fmt.Errorf("UNTIL and COUNT cannot be used together")
+	}
+	if rule.Freq != Monthly {
+		for _, day := range rule.ByDay {
+			if day.Ordinal != 0 {
+				return nil, fmt.Errorf("BYDAY ordinals are only supported with FREQ=MONTHLY")
+			}
+		}
+	}
+
+	return rule, nil
+}
+
+func parseWeekdayNum(value string) (WeekdayNum, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 {
+		return WeekdayNum{}, fmt.Errorf("invalid weekday %q", value)
+	}
+
+	weekday, ok := weekdayCodes[value[len(value)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("invalid weekday %q", value)
+	}
+
+	result := WeekdayNum{Weekday: weekday}
+
+	if prefix := value[:len(value)-2]; prefix != "" {
+		ordinal, err := strconv.Atoi(prefix)
+		if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+			return WeekdayNum{}, fmt.Errorf("invalid weekday ordinal %q", value)
+		}
+		result.Ordinal = ordinal
+	}
+
+	return result, nil
+}
+
+// parseUntil accepts the RFC 5545 DATE and UTC DATE-TIME forms.
+func parseUntil(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid UNTIL value %q", value)
+}
+
+// String formats the rule back into RRULE syntax.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			code := weekdayCode(day.Weekday)
+			if day.Ordinal != 0 {
+				code = strconv.Itoa(day.Ordinal) + code
+			}
+			days = append(days, code)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+
+	if r.Until != nil {
+		if r.untilIsDate {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func weekdayCode(weekday time.Weekday) string {
+	for code, day := range weekdayCodes {
+		if day == weekday {
+			return code
+		}
+	}
+	return ""
+}
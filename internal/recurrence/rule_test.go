@@ -0,0 +1,261 @@
+package recurrence
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Rule
+	}{
+		{"FREQ=WEEKLY", Rule{Freq: Weekly, Interval: 1}},
+		{"RRULE:freq=weekly;interval=2;byday=mo,th", Rule{
+			Freq:     Weekly,
+			Interval: 2,
+			ByDay:    []WeekdayNum{{Weekday: time.Monday}, {Weekday: time.Thursday}},
+		}},
+		{"FREQ=MONTHLY;BYDAY=2SU", Rule{
+			Freq:     Monthly,
+			Interval: 1,
+			ByDay:    []WeekdayNum{{Ordinal: 2, Weekday: time.Sunday}},
+		}},
+		{"FREQ=MONTHLY;BYDAY=-1FR", Rule{
+			Freq:     Monthly,
+			Interval: 1,
+			ByDay:    []WeekdayNum{{Ordinal: -1, Weekday: time.Friday}},
+		}},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,-1", Rule{Freq: Monthly, Interval: 1, ByMonthDay: []int{1, -1}}},
+		{"FREQ=DAILY;COUNT=10", Rule{Freq: Daily, Interval: 1, Count: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUntil(t *testing.T) {
+	date, err := Parse("FREQ=WEEKLY;UNTIL=20261231")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !date.untilIsDate || date.Until.Format("2006-01-02") != "2026-12-31" {
+		t.Errorf("UNTIL date parsed as %s (date: %v)", date.Until, date.untilIsDate)
+	}
+
+	dateTime, err := Parse("FREQ=WEEKLY;UNTIL=20261231T230000Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dateTime.untilIsDate || !dateTime.Until.Equal(time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC)) {
+		t.Errorf("UNTIL date-time parsed as %s (date: %v)", dateTime.Until, dateTime.untilIsDate)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	inputs := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=YEARLY",
+		"FREQ=WEEKLY;INTERVAL=0",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYDAY=6SU",
+		"FREQ=WEEKLY;BYDAY=2SU",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=WEEKLY;COUNT=0",
+		"FREQ=WEEKLY;UNTIL=tomorrow",
+		"FREQ=WEEKLY;COUNT=3;UNTIL=20261231",
+		"FREQ=WEEKLY;BYSETPOS=1",
+	}
+
+	for _, input := range inputs {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", input)
+		}
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	inputs := []string{
+		"FREQ=WEEKLY;BYDAY=SU",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+		"FREQ=MONTHLY;BYDAY=-1FR",
+		"FREQ=MONTHLY;BYMONTHDAY=-1",
+		"FREQ=DAILY;UNTIL=20261231",
+		"FREQ=DAILY;UNTIL=20261231T230000Z",
+		"FREQ=DAILY;COUNT=5",
+	}
+
+	for _, input := range inputs {
+		rule, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", input, err)
+		}
+		if got := rule.String(); got != input {
+			t.Errorf("Parse(%q).String() = %q", input, got)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		dtstart  string
+		from, to string
+		want     []string
+	}{
+		{
+			name:    "second Sunday",
+			rule:    "FREQ=MONTHLY;BYDAY=2SU",
+			dtstart: "2026-01-01 10:00",
+			from:    "2026-10-01 00:00", to: "2026-12-31 23:59",
+			want: []string{"2026-10-11 10:00", "2026-11-08 10:00", "2026-12-13 10:00"},
+		},
+		{
+			name:    "last Friday",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: "2026-01-01 19:00",
+			from:    "2026-10-01 00:00", to: "2026-12-31 23:59",
+			want: []string{"2026-10-30 19:00", "2026-11-27 19:00", "2026-12-25 19:00"},
+		},
+		{
+			name:    "last day of the month",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: "2026-01-01 18:00",
+			from:    "2026-10-01 00:00", to: "2027-02-28 23:59",
+			want: []string{
+				"2026-10-31 18:00", "2026-11-30 18:00", "2026-12-31 18:00",
+				"2027-01-31 18:00", "2027-02-28 18:00",
+			},
+		},
+		{
+			name:    "31st skips shorter months",
+			rule:    "FREQ=MONTHLY",
+			dtstart: "2026-01-31 18:00",
+			from:    "2026-01-01 00:00", to: "2026-05-31 23:59",
+			want: []string{"2026-01-31 18:00", "2026-03-31 18:00", "2026-05-31 18:00"},
+		},
+		{
+			name:    "every other week",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=TH",
+			dtstart: "2026-10-01 18:30",
+			from:    "2026-10-01 00:00", to: "2026-11-15 23:59",
+			want: []string{"2026-10-01 18:30", "2026-10-15 18:30", "2026-10-29 18:30", "2026-11-12 18:30"},
+		},
+		{
+			name:    "every other week starting long before from",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=TH",
+			dtstart: "2026-10-01 18:30",
+			from:    "2027-01-01 00:00", to: "2027-01-31 23:59",
+			want: []string{"2027-01-07 18:30", "2027-01-21 18:30"},
+		},
+		{
+			name:    "several weekdays",
+			rule:    "FREQ=WEEKLY;BYDAY=TU,SA",
+			dtstart: "2026-10-13 19:00",
+			from:    "2026-10-12 00:00", to: "2026-10-25 23:59",
+			want: []string{"2026-10-13 19:00", "2026-10-17 19:00", "2026-10-20 19:00", "2026-10-24 19:00"},
+		},
+		{
+			name:    "every third day",
+			rule:    "FREQ=DAILY;INTERVAL=3",
+			dtstart: "2026-10-01 07:00",
+			from:    "2026-10-05 00:00", to: "2026-10-12 23:59",
+			want: []string{"2026-10-07 07:00", "2026-10-10 07:00"},
+		},
+		{
+			name:    "count is counted from dtstart",
+			rule:    "FREQ=WEEKLY;COUNT=3",
+			dtstart: "2026-10-01 18:00",
+			from:    "2026-10-10 00:00", to: "2026-12-31 23:59",
+			want: []string{"2026-10-15 18:00"},
+		},
+		{
+			name:    "until date includes its day",
+			rule:    "FREQ=WEEKLY;UNTIL=20261015",
+			dtstart: "2026-10-01 18:00",
+			from:    "2026-10-01 00:00", to: "2026-12-31 23:59",
+			want: []string{"2026-10-01 18:00", "2026-10-08 18:00", "2026-10-15 18:00"},
+		},
+		{
+			name:    "until date-time",
+			rule:    "FREQ=WEEKLY;UNTIL=20261015T150000Z",
+			dtstart: "2026-10-01 18:00",
+			from:    "2026-10-01 00:00", to: "2026-12-31 23:59",
+			want: []string{"2026-10-01 18:00", "2026-10-08 18:00"},
+		},
+		{
+			name:    "dtstart in the week of the switch to summer time",
+			rule:    "FREQ=WEEKLY;BYDAY=SU",
+			dtstart: "2026-03-23 10:00",
+			from:    "2026-03-23 00:00", to: "2026-04-05 23:59",
+			want: []string{"2026-03-29 10:00", "2026-04-05 10:00"},
+		},
+		{
+			name:    "dtstart in the week of the switch to winter time",
+			rule:    "FREQ=DAILY",
+			dtstart: "2026-10-24 16:00",
+			from:    "2026-10-24 00:00", to: "2026-10-26 23:59",
+			want: []string{"2026-10-24 16:00", "2026-10-25 16:00", "2026-10-26 16:00"},
+		},
+		{
+			name:    "daily series anchored long ago",
+			rule:    "FREQ=DAILY",
+			dtstart: "2010-01-01 10:00",
+			from:    "2026-10-17 00:00", to: "2026-10-19 23:59",
+			want: []string{"2026-10-17 10:00", "2026-10-18 10:00", "2026-10-19 10:00"},
+		},
+		{
+			name:    "monthly series anchored long ago",
+			rule:    "FREQ=MONTHLY;INTERVAL=5;BYDAY=1SA",
+			dtstart: "1990-01-06 12:00",
+			from:    "2026-01-01 00:00", to: "2026-12-31 23:59",
+			want: []string{"2026-04-04 12:00", "2026-09-05 12:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+
+			var got []string
+			for _, occurrence := range rule.Between(warsaw(t, tt.dtstart), warsaw(t, tt.from), warsaw(t, tt.to)) {
+				if occurrence.Location() != clock.Location() {
+					t.Errorf("occurrence %s is not in Warsaw time", occurrence)
+				}
+				got = append(got, occurrence.Format("2006-01-02 15:04"))
+			}
+
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("Between() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func warsaw(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := clock.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/database"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category *models.Category) error
+	GetByID(ctx context.Context, id int) (*models.Category, error)
+	FindByName(ctx context.Context, name string) (*models.Category, error)
+	GetAll(ctx context.Context) ([]models.Category, error)
+	SetEmoji(ctx context.Context, id int, emoji string) error
+	Rename(ctx context.Context, id int, name string) error
+	Merge(ctx context.Context, fromID int, intoID int) error
+	Move(ctx context.Context, id int, delta int) error
+	Delete(ctx context.Context, id int) error
+	EventCounts(ctx context.Context) (map[string]int, error)
+}
+
+type categoryRepository struct{}
+
+func NewCategoryRepository() CategoryRepository {
+	return &categoryRepository{}
+}
+
+// Create adds the category at the end of the list.
+func (r *categoryRepository) Create(ctx context.Context, category *models.Category) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO categories (name, emoji, sort_order)
+		VALUES (?, ?, (SELECT COALESCE(MAX(sort_order), 0) + 1 FROM categories))
+	`
+
+	result, err := database.DB.ExecContext(ctx, query, category.Name, category.Emoji)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return fmt.Errorf("database write timeout creating category: %w", err)
+		}
+		return fmt.Errorf("failed to create category %q: %w", category.Name, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	category.ID = int(id)
+
+	return nil
+}
+
+func (r *categoryRepository) GetByID(ctx context.Context, id int) (*models.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var category models.Category
+	query := `SELECT * FROM categories WHERE id = ?`
+
+	err := database.DB.GetContext(ctx, &category, query, id)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database read timeout for category %d: %w", id, err)
+		}
+		return nil, fmt.Errorf("failed to get category %d: %w", id, err)
+	}
+
+	return &category, nil
+}
+
+// FindByName returns the category with the given name ignoring case, or nil
+// if there is none. SQLite only folds ASCII, so names are compared in Go.
+func (r *categoryRepository) FindByName(ctx context.Context, name string) (*models.Category, error) {
+	categories, err := r.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	for i := range categories {
+		if strings.EqualFold(categories[i].Name, name) {
+			return &categories[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *categoryRepository) GetAll(ctx context.Context) ([]models.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var categories []models.Category
+	query := `SELECT * FROM categories ORDER BY sort_order, id`
+
+	err := database.DB.SelectContext(ctx, &categories, query)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database read timeout for categories: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (r *categoryRepository) SetEmoji(ctx context.Context, id int, emoji string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `UPDATE categories SET emoji = ? WHERE id = ?`
+
+	_, err := database.DB.ExecContext(ctx, query, emoji, id)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return fmt.Errorf("database write timeout updating category %d: %w", id, err)
+		}
+		return fmt.Errorf("failed to update emoji of category %d: %w", id, err)
+	}
+	return nil
+}
+
+// Rename changes the category name together with the category of its events.
+func (r *categoryRepository) Rename(ctx context.Context, id int, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tx, err := database.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin renaming category %d: %w", id, err)
+	}
+	defer tx.Rollback()
+
+	var oldName string
+	if err := tx.GetContext(ctx, &oldName, `SELECT name FROM categories WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to get category %d: %w", id, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE categories SET name = ? WHERE id = ?`, name, id); err != nil {
+		return fmt.Errorf("failed to rename category %d: %w", id, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE events SET category = ? WHERE category = ?`, name, oldName); err != nil {
+		return fmt.Errorf("failed to rename category of events: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit renaming category %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// Merge moves the events of one category to another and deletes the first
+// one, e.g. to fold "Youth" into "Молодіжка".
+func (r *categoryRepository) Merge(ctx context.Context, fromID int, intoID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tx, err := database.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin merging category %d: %w", fromID, err)
+	}
+	defer tx.Rollback()
+
+	var fromName, intoName string
+	if err := tx.GetContext(ctx, &fromName, `SELECT name FROM categories WHERE id = ?`, fromID); err != nil {
+		return fmt.Errorf("failed to get category %d: %w", fromID, err)
+	}
+	if err := tx.GetContext(ctx, &intoName, `SELECT name FROM categories WHERE id = ?`, intoID); err != nil {
+		return fmt.Errorf("failed to get category %d: %w", intoID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE events SET category = ? WHERE category = ?`, intoName, fromName); err != nil {
+		return fmt.Errorf("failed to move events to category %d: %w", intoID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM categories WHERE id = ?`, fromID); err != nil {
+		return fmt.Errorf("failed to delete category %d: %w", fromID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit merging category %d: %w", fromID, err)
+	}
+
+	return nil
+}
+
+// Move shifts the category delta places up (negative) or down the list.
+// Positions are renumbered, so categories seeded with equal sort orders
+// move predictably.
+func (r *categoryRepository) Move(ctx context.Context, id int, delta int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := database.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin moving category %d: %w", id, err)
+	}
+	defer tx.Rollback()
+
+	var ids []int
+	if err := tx.SelectContext(ctx, &ids, `SELECT id FROM categories ORDER BY sort_order, id`); err != nil {
+		return fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	for i := range ids {
+		if ids[i] != id {
+			continue
+		}
+		j := max(0, min(i+delta, len(ids)-1))
+		ids[i], ids[j] = ids[j], ids[i]
+		break
+	}
+
+	for i, categoryID := range ids {
+		if _, err := tx.ExecContext(ctx, `UPDATE categories SET sort_order = ? WHERE id = ?`, i+1, categoryID); err != nil {
+			return fmt.Errorf("failed to reorder category %d: %w", categoryID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit moving category %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// Delete removes the category; its events are left without a category.
+func (r *categoryRepository) Delete(ctx context.Context, id int) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tx, err := database.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin deleting category %d: %w", id, err)
+	}
+	defer tx.Rollback()
+
+	var name string
+	if err := tx.GetContext(ctx, &name, `SELECT name FROM categories WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to get category %d: %w", id, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE events SET category = NULL WHERE category = ?`, name); err != nil {
+		return fmt.Errorf("failed to clear category of events: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM categories WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete category %d: %w", id, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit deleting category %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// EventCounts returns the number of events per category name.
+func (r *categoryRepository) EventCounts(ctx context.Context) (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var rows []struct {
+		Category string `db:"category"`
+		Count    int    `db:"count"`
+	}
+	query := `SELECT category, COUNT(*) AS count FROM events WHERE category IS NOT NULL GROUP BY category`
+
+	err := database.DB.SelectContext(ctx, &rows, query)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database read timeout for category counts: %w", err)
+		}
+		return nil, fmt.Errorf("failed to count events by category: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Category] = row.Count
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,381 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/database"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+type EventRegistrationRepository interface {
+	Register(ctx context.Context, eventID int, userID int64, capacity int) (*models.EventRegistration, error)
+	Cancel(ctx context.Context, eventID int, userID int64, capacity int) ([]models.EventRegistration, error)
+	Promote(ctx context.Context, eventID int, capacity int) ([]models.EventRegistration, error)
+	Get(ctx context.Context, eventID int, userID int64) (*models.EventRegistration, error)
+	GetByEvent(ctx context.Context, eventID int) ([]models.EventRegistration, error)
+	GetAttendees(ctx context.Context, eventID int) ([]models.Attendee, error)
+	SetCheckedIn(ctx context.Context, registrationID int, checkedIn bool) error
+	WaitlistPosition(ctx context.Context, registration *models.EventRegistration) (int, error)
+	Counts(ctx context.Context) (map[int]models.RegistrationCounts, error)
+	CountsForEvent(ctx context.Context, eventID int) (models.RegistrationCounts, error)
+	DeleteByEvent(ctx context.Context, eventID int) error
+}
+
+type eventRegistrationRepository struct{}
+
+func NewEventRegistrationRepository() EventRegistrationRepository {
+	return &eventRegistrationRepository{}
+}
+
+// Register signs the user up for the event. Once capacity (if non-zero) is
+// reached, new registrations go to the waitlist. Registering twice returns
+// the existing registration unchanged.
+func (r *eventRegistrationRepository) Register(ctx context.Context, eventID int, userID int64, capacity int) (*models.EventRegistration, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := database.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin registration for event %d: %w", eventID, err)
+	}
+	defer tx.Rollback()
+
+	var existing models.EventRegistration
+	err = tx.GetContext(ctx, &existing, `SELECT * FROM event_registrations WHERE event_id = ? AND user_id = ?`, eventID, userID)
+	if err == nil {
+		return &existing, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check registration for event %d: %w", eventID, err)
+	}
+
+	status := models.RegistrationGoing
+	if capacity > 0 {
+		var going int
+		err = tx.GetContext(ctx, &going, `SELECT COUNT(*) FROM event_registrations WHERE event_id = ? AND status = ?`, eventID, models.RegistrationGoing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count registrations for event %d: %w", eventID, err)
+		}
+		if going >= capacity {
+			status = models.RegistrationWaitlist
+		}
+	}
+
+	registration := &models.EventRegistration{
+		EventID:   eventID,
+		UserID:    userID,
+		Status:    status,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	result, err := tx.NamedExecContext(ctx, `
+		INSERT INTO event_registrations (event_id, user_id, status, created_at)
+		VALUES (:event_id, :user_id, :status, :created_at)
+	`, registration)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database write timeout registering for event %d: %w", eventID, err)
+		}
+		return nil, fmt.Errorf("failed to register for event %d: %w", eventID, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	registration.ID = int(id)
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit registration for event %d: %w", eventID, err)
+	}
+
+	return registration, nil
+}
+
+// Cancel removes the user's registration. When that frees a place, the
+// earliest waitlisted users are moved up and returned so they can be told.
+func (r *eventRegistrationRepository) Cancel(ctx context.Context, eventID int, userID int64, capacity int) ([]models.EventRegistration, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := database.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin cancellation for event %d: %w", eventID, err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.GetContext(ctx, &status, `SELECT status FROM event_registrations WHERE event_id = ? AND user_id = ?`, eventID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registration for event %d: %w", eventID, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM event_registrations WHERE event_id = ? AND user_id = ?`, eventID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel registration for event %d: %w", eventID, err)
+	}
+
+	var promoted []models.EventRegistration
+
+	if status == models.RegistrationGoing {
+		promoted, err = promoteFromWaitlist(ctx, tx, eventID, capacity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit cancellation for event %d: %w", eventID, err)
+	}
+
+	return promoted, nil
+}
+
+// promoteFromWaitlist moves waitlisted users to the attendees, earliest
+// first, while there are free places.
+func promoteFromWaitlist(ctx context.Context, tx *sqlx.Tx, eventID int, capacity int) ([]models.EventRegistration, error) {
+	var waitlist []models.EventRegistration
+	err := tx.SelectContext(ctx, &waitlist, `
+		SELECT * FROM event_registrations
+		WHERE event_id = ? AND status = ?
+		ORDER BY id ASC
+	`, eventID, models.RegistrationWaitlist)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist for event %d: %w", eventID, err)
+	}
+
+	if capacity > 0 {
+		var going int
+		err := tx.GetContext(ctx, &going, `SELECT COUNT(*) FROM event_registrations WHERE event_id = ? AND status = ?`, eventID, models.RegistrationGoing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count registrations for event %d: %w", eventID, err)
+		}
+		waitlist = waitlist[:max(0, min(len(waitlist), capacity-going))]
+	}
+
+	for i := range waitlist {
+		_, err := tx.ExecContext(ctx, `UPDATE event_registrations SET status = ? WHERE id = ?`, models.RegistrationGoing, waitlist[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to promote registration %d: %w", waitlist[i].ID, err)
+		}
+		waitlist[i].Status = models.RegistrationGoing
+	}
+
+	return waitlist, nil
+}
+
+// Promote fills free places from the waitlist, e.g. after the capacity of
+// the event was raised, and returns the promoted registrations.
+func (r *eventRegistrationRepository) Promote(ctx context.Context, eventID int, capacity int) ([]models.EventRegistration, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := database.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin promotion for event %d: %w", eventID, err)
+	}
+	defer tx.Rollback()
+
+	promoted, err := promoteFromWaitlist(ctx, tx, eventID, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit promotion for event %d: %w", eventID, err)
+	}
+
+	return promoted, nil
+}
+
+// Get returns the user's registration for the event, or nil if there is none.
+func (r *eventRegistrationRepository) Get(ctx context.Context, eventID int, userID int64) (*models.EventRegistration, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var registration models.EventRegistration
+	query := `SELECT * FROM event_registrations WHERE event_id = ? AND user_id = ?`
+
+	err := database.DB.GetContext(ctx, &registration, query, eventID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database read timeout for registration to event %d: %w", eventID, err)
+		}
+		return nil, fmt.Errorf("failed to get registration to event %d: %w", eventID, err)
+	}
+
+	return &registration, nil
+}
+
+func (r *eventRegistrationRepository) GetByEvent(ctx context.Context, eventID int) ([]models.EventRegistration, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var registrations []models.EventRegistration
+	query := `SELECT * FROM event_registrations WHERE event_id = ? ORDER BY id ASC`
+
+	err := database.DB.SelectContext(ctx, &registrations, query, eventID)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database read timeout for registrations of event %d: %w", eventID, err)
+		}
+		return nil, fmt.Errorf("failed to get registrations of event %d: %w", eventID, err)
+	}
+
+	return registrations, nil
+}
+
+// GetAttendees returns the registrations of the event with the users' names,
+// attendees first and then the waitlist, each in registration order.
+func (r *eventRegistrationRepository) GetAttendees(ctx context.Context, eventID int) ([]models.Attendee, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var attendees []models.Attendee
+	query := `
+		SELECT r.*, COALESCE(u.first_name, '') AS first_name, COALESCE(u.username, '') AS username
+		FROM event_registrations r
+		LEFT JOIN users u ON u.user_id = r.user_id
+		WHERE r.event_id = ?
+		ORDER BY r.status = ? DESC, r.id ASC
+	`
+
+	err := database.DB.SelectContext(ctx, &attendees, query, eventID, models.RegistrationGoing)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database read timeout for attendees of event %d: %w", eventID, err)
+		}
+		return nil, fmt.Errorf("failed to get attendees of event %d: %w", eventID, err)
+	}
+
+	return attendees, nil
+}
+
+func (r *eventRegistrationRepository) SetCheckedIn(ctx context.Context, registrationID int, checkedIn bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var checkedInAt *time.Time
+	if checkedIn {
+		now := time.Now().UTC()
+		checkedInAt = &now
+	}
+
+	query := `UPDATE event_registrations SET checked_in_at = ? WHERE id = ?`
+
+	_, err := database.DB.ExecContext(ctx, query, checkedInAt, registrationID)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return fmt.Errorf("database write timeout checking in registration %d: %w", registrationID, err)
+		}
+		return fmt.Errorf("failed to check in registration %d: %w", registrationID, err)
+	}
+	return nil
+}
+
+// WaitlistPosition returns the 1-based place of the registration in the
+// waitlist of its event.
+func (r *eventRegistrationRepository) WaitlistPosition(ctx context.Context, registration *models.EventRegistration) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var position int
+	query := `SELECT COUNT(*) FROM event_registrations WHERE event_id = ? AND status = ? AND id <= ?`
+
+	err := database.DB.GetContext(ctx, &position, query, registration.EventID, models.RegistrationWaitlist, registration.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get waitlist position for event %d: %w", registration.EventID, err)
+	}
+
+	return position, nil
+}
+
+// Counts returns the number of attendees, waitlisted and checked-in users
+// per event.
+func (r *eventRegistrationRepository) Counts(ctx context.Context) (map[int]models.RegistrationCounts, error) {
+	counts, err := selectRegistrationCounts(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registration counts: %w", err)
+	}
+	return counts, nil
+}
+
+// CountsForEvent returns the number of attendees, waitlisted and checked-in
+// users of one event.
+func (r *eventRegistrationRepository) CountsForEvent(ctx context.Context, eventID int) (models.RegistrationCounts, error) {
+	counts, err := selectRegistrationCounts(ctx, "WHERE event_id = ?", eventID)
+	if err != nil {
+		return models.RegistrationCounts{}, fmt.Errorf("failed to get registration counts for event %d: %w", eventID, err)
+	}
+	return counts[eventID], nil
+}
+
+// selectRegistrationCounts sums up the registrations matching condition per
+// event.
+func selectRegistrationCounts(ctx context.Context, condition string, args ...any) (map[int]models.RegistrationCounts, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var rows []struct {
+		EventID   int    `db:"event_id"`
+		Status    string `db:"status"`
+		Count     int    `db:"count"`
+		CheckedIn int    `db:"checked_in"`
+	}
+	query := `
+		SELECT event_id, status, COUNT(*) AS count, COUNT(checked_in_at) AS checked_in
+		FROM event_registrations
+		` + condition + `
+		GROUP BY event_id, status
+	`
+
+	err := database.DB.SelectContext(ctx, &rows, query, args...)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database read timeout: %w", err)
+		}
+		return nil, err
+	}
+
+	counts := make(map[int]models.RegistrationCounts)
+	for _, row := range rows {
+		c := counts[row.EventID]
+		switch row.Status {
+		case models.RegistrationGoing:
+			c.Going = row.Count
+		case models.RegistrationWaitlist:
+			c.Waitlist = row.Count
+		}
+		c.CheckedIn += row.CheckedIn
+		counts[row.EventID] = c
+	}
+
+	return counts, nil
+}
+
+func (r *eventRegistrationRepository) DeleteByEvent(ctx context.Context, eventID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `DELETE FROM event_registrations WHERE event_id = ?`
+
+	_, err := database.DB.ExecContext(ctx, query, eventID)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return fmt.Errorf("database write timeout deleting registrations of event %d: %w", eventID, err)
+		}
+		return fmt.Errorf("failed to delete registrations of event %d: %w", eventID, err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/database"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+type EventReminderRepository interface {
+	Add(ctx context.Context, eventID int, offsetMinutes int) error
+	Remove(ctx context.Context, eventID int, offsetMinutes int) error
+	GetByEvent(ctx context.Context, eventID int) ([]models.EventReminder, error)
+	GetAll(ctx context.Context) ([]models.EventReminder, error)
+	DeleteByEvent(ctx context.Context, eventID int) error
+}
+
+type eventReminderRepository struct{}
+
+func NewEventReminderRepository() EventReminderRepository {
+	return &eventReminderRepository{}
+}
+
+func (r *eventReminderRepository) Add(ctx context.Context, eventID int, offsetMinutes int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `INSERT OR IGNORE INTO event_reminders (event_id, offset_minutes) VALUES (?, ?)`
+
+	_, err := database.DB.ExecContext(ctx, query, eventID, offsetMinutes)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return fmt.Errorf("database write timeout adding reminder for event %d: %w", eventID, err)
+		}
+		return fmt.Errorf("failed to add reminder for event %d: %w", eventID, err)
+	}
+	return nil
+}
+
+func (r *eventReminderRepository) Remove(ctx context.Context, eventID int, offsetMinutes int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `DELETE FROM event_reminders WHERE event_id = ? AND offset_minutes = ?`
+
+	_, err := database.DB.ExecContext(ctx, query, eventID, offsetMinutes)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return fmt.Errorf("database write timeout removing reminder for event %d: %w", eventID, err)
+		}
+		return fmt.Errorf("failed to remove reminder for event %d: %w", eventID, err)
+	}
+	return nil
+}
+
+func (r *eventReminderRepository) GetByEvent(ctx context.Context, eventID int) ([]models.EventReminder, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var reminders []models.EventReminder
+	query := `SELECT * FROM event_reminders WHERE event_id = ? ORDER BY offset_minutes DESC`
+
+	err := database.DB.SelectContext(ctx, &reminders, query, eventID)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database read timeout for reminders of event %d: %w", eventID, err)
+		}
+		return nil, fmt.Errorf("failed to get reminders of event %d: %w", eventID, err)
+	}
+
+	return reminders, nil
+}
+
+func (r *eventReminderRepository) GetAll(ctx context.Context) ([]models.EventReminder, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var reminders []models.EventReminder
+	query := `SELECT * FROM event_reminders ORDER BY event_id, offset_minutes DESC`
+
+	err := database.DB.SelectContext(ctx, &reminders, query)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database read timeout for event reminders: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get event reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+func (r *eventReminderRepository) DeleteByEvent(ctx context.Context, eventID int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `DELETE FROM event_reminders WHERE event_id = ?`
+
+	_, err := database.DB.ExecContext(ctx, query, eventID)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return fmt.Errorf("database write timeout deleting reminders of event %d: %w", eventID, err)
+		}
+		return fmt.Errorf("failed to delete reminders of event %d: %w", eventID, err)
+	}
+	return nil
+}
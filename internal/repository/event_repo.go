@@ -5,17 +5,29 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/database"
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
 )
 
 type EventRepository interface {
 	Create(ctx context.Context, event *models.Event) error
+	CreateMany(ctx context.Context, events []*models.Event) error
 	GetByID(ctx context.Context, id int) (*models.Event, error)
 	GetAll(ctx context.Context) ([]models.Event, error)
 	GetUpcoming(ctx context.Context) ([]models.Event, error)
+	GetCurrent(ctx context.Context) ([]models.Event, error)
+	GetPast(ctx context.Context) ([]models.Event, error)
+	GetEndedBefore(ctx context.Context, before time.Time) ([]models.Event, error)
+	ArchiveEndedBefore(ctx context.Context, before time.Time) (int64, error)
+	DeleteMany(ctx context.Context, ids []int) error
+	GetPublishedBetween(ctx context.Context, from, to time.Time) ([]models.Event, error)
 	Update(ctx context.Context, event *models.Event) error
 	Delete(ctx context.Context, id int) error
+	SetPublished(ctx context.Context, id int, isPublished bool) error
+	GetDueForPublish(ctx context.Context, now time.Time) ([]models.Event, error)
+	MarkAnnounced(ctx context.Context, id int) (bool, error)
+	ClearAnnounced(ctx context.Context, id int) error
 }
 
 type eventRepository struct{}
@@ -29,11 +41,11 @@ func (r *eventRepository) Create(ctx context.Context, event *models.Event) error
 	defer cancel()
 
 	query := `
-		INSERT INTO events (title, description, date, location, category, registration_url, is_published, created_at, created_by)
-		VALUES (:title, :description, :date, :location, :category, :registration_url, :is_published, :created_at, :created_by)
+		INSERT INTO events (title, description, date, end_date, all_day, location, latitude, longitude, category, registration_url, report_url, image_file_id, is_published, publish_at, capacity, created_at, created_by)
+		VALUES (:title, :description, :date, :end_date, :all_day, :location, :latitude, :longitude, :category, :registration_url, :report_url, :image_file_id, :is_published, :publish_at, :capacity, :created_at, :created_by)
 	`
 
-	result, err := database.DB.NamedExecContext(ctx, query, event)
+	result, err := database.DB.NamedExecContext(ctx, query, toStorage(event))
 	if err != nil {
 		if err == context.DeadlineExceeded {
 			return fmt.Errorf("database write timeout creating event: %w", err)
@@ -50,6 +62,46 @@ func (r *eventRepository) Create(ctx context.Context, event *models.Event) error
 	return nil
 }
 
+// CreateMany inserts all events in one transaction: either every event is
+// created or none is.
+func (r *eventRepository) CreateMany(ctx context.Context, events []*models.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	tx, err := database.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin events import: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO events (title, description, date, end_date, all_day, location, latitude, longitude, category, registration_url, report_url, image_file_id, is_published, publish_at, capacity, created_at, created_by)
+		VALUES (:title, :description, :date, :end_date, :all_day, :location, :latitude, :longitude, :category, :registration_url, :report_url, :image_file_id, :is_published, :publish_at, :capacity, :created_at, :created_by)
+	`
+
+	for i, event := range events {
+		result, err := tx.NamedExecContext(ctx, query, toStorage(event))
+		if err != nil {
+			if err == context.DeadlineExceeded {
+				return fmt.Errorf("database write timeout importing event %d: %w", i+1, err)
+			}
+			return fmt.Errorf("failed to import event %d (%s): %w", i+1, event.Title, err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
+		event.ID = int(id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit events import: %w", err)
+	}
+
+	return nil
+}
+
 func (r *eventRepository) GetByID(ctx context.Context, id int) (*models.Event, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
@@ -65,6 +117,8 @@ func (r *eventRepository) GetByID(ctx context.Context, id int) (*models.Event, e
 		return nil, fmt.Errorf("failed to get event %d: %w", id, err)
 	}
 
+	fromStorage(&event)
+
 	return &event, nil
 }
 
@@ -73,12 +127,7 @@ func (r *eventRepository) GetAll(ctx context.Context) ([]models.Event, error) {
 	defer cancel()
 
 	var events []models.Event
-	query := `
-		SELECT * FROM events 
-		ORDER BY
-			CASE WHEN date >= datetime('now') THEN 0 ELSE 1 END,
-			date ASC
-	`
+	query := `SELECT * FROM events ORDER BY date ASC`
 
 	err := database.DB.SelectContext(ctx, &events, query)
 	if err != nil {
@@ -88,7 +137,7 @@ func (r *eventRepository) GetAll(ctx context.Context) ([]models.Event, error) {
 		return nil, fmt.Errorf("failed to get all events: %w", err)
 	}
 
-	return events, nil
+	return fromStorageAll(events), nil
 }
 
 func (r *eventRepository) GetUpcoming(ctx context.Context) ([]models.Event, error) {
@@ -97,16 +146,15 @@ func (r *eventRepository) GetUpcoming(ctx context.Context) ([]models.Event, erro
 
 	var events []models.Event
 
-	location, _ := time.LoadLocation("Europe/Warsaw")
-	now := time.Now().In(location)
+	now, today := storedNow()
 
 	query := `
 		SELECT * FROM events
-		WHERE date >= ? AND is_published = 1
+		WHERE is_published = 1 AND ` + notEndedCondition + `
 		ORDER BY date ASC
 	`
 
-	err := database.DB.SelectContext(ctx, &events, query, now)
+	err := database.DB.SelectContext(ctx, &events, query, today, now)
 	if err != nil {
 		if err == context.DeadlineExceeded {
 			return nil, fmt.Errorf("database read timeout for upcoming events: %w", err)
@@ -114,7 +162,136 @@ func (r *eventRepository) GetUpcoming(ctx context.Context) ([]models.Event, erro
 		return nil, fmt.Errorf("failed to get upcoming events: %w", err)
 	}
 
-	return events, nil
+	return fromStorageAll(events), nil
+}
+
+// notEndedCondition matches events that have not ended yet: all-day events
+// until their last day is over, timed ones until their end time or, without
+// one, their start. It takes today and now from storedNow.
+const notEndedCondition = `(
+	(all_day = 1 AND COALESCE(end_date, date) >= ?) OR
+	(all_day = 0 AND COALESCE(end_date, date) >= ?)
+)`
+
+// storedNow returns the current minute and the start of the current Warsaw
+// day in the form event dates are stored in.
+func storedNow() (now, today time.Time) {
+	return clock.Now().Truncate(time.Minute).UTC(), clock.Today().UTC()
+}
+
+// GetCurrent returns all events that have not ended yet, drafts included.
+func (r *eventRepository) GetCurrent(ctx context.Context) ([]models.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var events []models.Event
+
+	now, today := storedNow()
+
+	query := `SELECT * FROM events WHERE ` + notEndedCondition + ` ORDER BY date ASC`
+
+	err := database.DB.SelectContext(ctx, &events, query, today, now)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database read timeout for current events: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get current events: %w", err)
+	}
+
+	return fromStorageAll(events), nil
+}
+
+// GetPast returns all events that have ended, the most recent first.
+func (r *eventRepository) GetPast(ctx context.Context) ([]models.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var events []models.Event
+
+	now, today := storedNow()
+
+	query := `SELECT * FROM events WHERE NOT ` + notEndedCondition + ` ORDER BY date DESC`
+
+	err := database.DB.SelectContext(ctx, &events, query, today, now)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database read timeout for past events: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get past events: %w", err)
+	}
+
+	return fromStorageAll(events), nil
+}
+
+// GetEndedBefore returns events whose last day is before the given date.
+func (r *eventRepository) GetEndedBefore(ctx context.Context, before time.Time) ([]models.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var events []models.Event
+
+	query := `SELECT * FROM events WHERE COALESCE(end_date, date) < ? ORDER BY date ASC`
+
+	err := database.DB.SelectContext(ctx, &events, query, before.UTC())
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database read timeout for events ended before %s: %w", before.Format("02.01.2006"), err)
+		}
+		return nil, fmt.Errorf("failed to get events ended before %s: %w", before.Format("02.01.2006"), err)
+	}
+
+	return fromStorageAll(events), nil
+}
+
+// ArchiveEndedBefore archives the events whose last day is before the given
+// date and returns how many were archived.
+func (r *eventRepository) ArchiveEndedBefore(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `UPDATE events SET archived_at = ? WHERE archived_at IS NULL AND COALESCE(end_date, date) < ?`
+
+	result, err := database.DB.ExecContext(ctx, query, time.Now().UTC(), before.UTC())
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return 0, fmt.Errorf("database write timeout archiving events: %w", err)
+		}
+		return 0, fmt.Errorf("failed to archive events ended before %s: %w", before.Format("02.01.2006"), err)
+	}
+
+	archived, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected for archived events: %w", err)
+	}
+
+	return archived, nil
+}
+
+// GetPublishedBetween returns published, unarchived events that take place
+// within [from, to), including multi-day events that started before from.
+func (r *eventRepository) GetPublishedBetween(ctx context.Context, from, to time.Time) ([]models.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var events []models.Event
+
+	query := `
+		SELECT * FROM events
+		WHERE COALESCE(end_date, date) >= ? AND date < ? AND is_published = 1 AND archived_at IS NULL
+		ORDER BY date ASC
+	`
+
+	err := database.DB.SelectContext(ctx, &events, query, from.UTC(), to.UTC())
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database read timeout for events between %s and %s: %w",
+				from.Format("02.01.2006"), to.Format("02.01.2006"), err)
+		}
+		return nil, fmt.Errorf("failed to get events between %s and %s: %w",
+			from.Format("02.01.2006"), to.Format("02.01.2006"), err)
+	}
+
+	return fromStorageAll(events), nil
 }
 
 func (r *eventRepository) Update(ctx context.Context, event *models.Event) error {
@@ -126,13 +303,21 @@ func (r *eventRepository) Update(ctx context.Context, event *models.Event) error
 		SET title = :title,
 			description = :description,
 			date = :date,
+			end_date = :end_date,
+			all_day = :all_day,
 			location = :location,
+			latitude = :latitude,
+			longitude = :longitude,
 			category = :category,
 			registration_url = :registration_url,
-			is_published = :is_published
+			report_url = :report_url,
+			image_file_id = :image_file_id,
+			is_published = :is_published,
+			publish_at = :publish_at,
+			capacity = :capacity
 		WHERE id = :id
 	`
-	_, err := database.DB.NamedExecContext(ctx, query, event)
+	_, err := database.DB.NamedExecContext(ctx, query, toStorage(event))
 	if err != nil {
 		if err == context.DeadlineExceeded {
 			return fmt.Errorf("database write timeout updating event %d: %w", event.ID, err)
@@ -157,3 +342,171 @@ func (r *eventRepository) Delete(ctx context.Context, id int) error {
 	}
 	return nil
 }
+
+// DeleteMany deletes the events together with their registrations and
+// reminder settings in one transaction.
+func (r *eventRepository) DeleteMany(ctx context.Context, ids []int) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	tx, err := database.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	queries := []string{
+		`DELETE FROM event_registrations WHERE event_id = ?`,
+		`DELETE FROM event_reminders WHERE event_id = ?`,
+		`DELETE FROM events WHERE id = ?`,
+	}
+
+	for _, id := range ids {
+		for _, query := range queries {
+			if _, err := tx.ExecContext(ctx, query, id); err != nil {
+				if err == context.DeadlineExceeded {
+					return fmt.Errorf("database write timeout deleting event %d: %w", id, err)
+				}
+				return fmt.Errorf("failed to delete event %d: %w", id, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit deleted events: %w", err)
+	}
+
+	return nil
+}
+
+// SetPublished publishes or hides the event. Either way a pending scheduled
+// publish time is cleared.
+func (r *eventRepository) SetPublished(ctx context.Context, id int, isPublished bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `UPDATE events SET is_published = ?, publish_at = NULL WHERE id = ?`
+
+	_, err := database.DB.ExecContext(ctx, query, isPublished, id)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return fmt.Errorf("database write timeout publishing event %d: %w", id, err)
+		}
+		return fmt.Errorf("failed to set published status for event %d: %w", id, err)
+	}
+	return nil
+}
+
+// GetDueForPublish returns drafts whose scheduled publish time is not after now.
+func (r *eventRepository) GetDueForPublish(ctx context.Context, now time.Time) ([]models.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var events []models.Event
+
+	query := `
+		SELECT * FROM events
+		WHERE is_published = 0 AND publish_at IS NOT NULL AND publish_at <= ?
+		ORDER BY publish_at ASC
+	`
+
+	err := database.DB.SelectContext(ctx, &events, query, now.UTC())
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return nil, fmt.Errorf("database read timeout for events due for publishing: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get events due for publishing: %w", err)
+	}
+
+	return fromStorageAll(events), nil
+}
+
+// MarkAnnounced records that the published event was announced to
+// subscribers. It returns false if the event is not published or was
+// already announced, so concurrent requests send the announcement once.
+func (r *eventRepository) MarkAnnounced(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `UPDATE events SET announced_at = ? WHERE id = ? AND is_published = 1 AND announced_at IS NULL`
+
+	result, err := database.DB.ExecContext(ctx, query, time.Now().UTC(), id)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return false, fmt.Errorf("database write timeout announcing event %d: %w", id, err)
+		}
+		return false, fmt.Errorf("failed to mark event %d as announced: %w", id, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected for event %d: %w", id, err)
+	}
+
+	return affected == 1, nil
+}
+
+// ClearAnnounced allows the event to be announced again, for announcements
+// that could not be sent at all.
+func (r *eventRepository) ClearAnnounced(ctx context.Context, id int) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `UPDATE events SET announced_at = NULL WHERE id = ?`
+
+	_, err := database.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return fmt.Errorf("database write timeout clearing announcement of event %d: %w", id, err)
+		}
+		return fmt.Errorf("failed to clear announcement of event %d: %w", id, err)
+	}
+	return nil
+}
+
+// toStorage returns a copy of the event with its times in UTC. Times are
+// compared as text in queries, so they must all be stored in one zone.
+func toStorage(event *models.Event) *models.Event {
+	stored := *event
+	stored.Date = event.Date.UTC()
+	stored.EndDate = utcTime(event.EndDate)
+	stored.PublishAt = utcTime(event.PublishAt)
+	stored.ArchivedAt = utcTime(event.ArchivedAt)
+	stored.AnnouncedAt = utcTime(event.AnnouncedAt)
+	stored.CreatedAt = event.CreatedAt.UTC()
+	return &stored
+}
+
+// fromStorage converts the times of an event read from the database to
+// Warsaw time.
+func fromStorage(event *models.Event) {
+	event.Date = clock.In(event.Date)
+	event.EndDate = warsawTime(event.EndDate)
+	event.PublishAt = warsawTime(event.PublishAt)
+	event.ArchivedAt = warsawTime(event.ArchivedAt)
+	event.AnnouncedAt = warsawTime(event.AnnouncedAt)
+	event.CreatedAt = clock.In(event.CreatedAt)
+}
+
+func fromStorageAll(events []models.Event) []models.Event {
+	for i := range events {
+		fromStorage(&events[i])
+	}
+	return events
+}
+
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+func warsawTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	local := clock.In(*t)
+	return &local
+}
@@ -18,6 +18,7 @@ type RecurringEventRepository interface {
 	Update(ctx context.Context, event *models.RecurringEvent) error
 	Delete(ctx context.Context, id int) error
 	SetActive(ctx context.Context, id int, isActive bool) error
+	SetHolidayPolicy(ctx context.Context, id int, policy string) error
 }
 
 type recurringEventRepository struct{}
@@ -33,15 +34,17 @@ func (r *recurringEventRepository) Create(ctx context.Context, event *models.Rec
 	query := `
 		INSERT INTO recurring_events (
 			title, description, day_of_week, event_time,
+			rrule, start_date,
 			reminder_day_offset, reminder_time,
 			location, category, registration_url,
-			is_active, created_at, created_by
+			holiday_policy, is_active, created_at, created_by
 		)
 		VALUES (
 			:title, :description, :day_of_week, :event_time,
+			:rrule, :start_date,
 			:reminder_day_offset, :reminder_time,
 			:location, :category, :registration_url,
-			:is_active, :created_at, :created_by
+			:holiday_policy, :is_active, :created_at, :created_by
 		)
 	`
 	result, err := database.DB.NamedExecContext(ctx, query, event)
@@ -115,11 +118,14 @@ func (r *recurringEventRepository) Update(ctx context.Context, event *models.Rec
 			description = :description,
 			day_of_week = :day_of_week,
 			event_time = :event_time,
+			rrule = :rrule,
+			start_date = :start_date,
 			reminder_day_offset = :reminder_day_offset,
 			reminder_time = :reminder_time,
 			location = :location,
 			category = :category,
 			registration_url = :registration_url,
+			holiday_policy = :holiday_policy,
 			is_active = :is_active
 		WHERE id = :id
 	`
@@ -143,6 +149,12 @@ func (r *recurringEventRepository) Delete(ctx context.Context, id int) error {
 		return fmt.Errorf("failed to delete recurring event %d: %w", id, err)
 	}
 
+	query = `DELETE FROM recurring_event_exceptions WHERE recurring_event_id = ?`
+	_, err = database.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete exceptions of recurring event %d: %w", id, err)
+	}
+
 	log.Printf("✅ Deleted recurring event ID: %d", id)
 
 	return nil
@@ -160,3 +172,16 @@ func (r *recurringEventRepository) SetActive(ctx context.Context, id int, isActi
 
 	return nil
 }
+
+func (r *recurringEventRepository) SetHolidayPolicy(ctx context.Context, id int, policy string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `UPDATE recurring_events SET holiday_policy = ? WHERE id = ?`
+	_, err := database.DB.ExecContext(ctx, query, policy, id)
+	if err != nil {
+		return fmt.Errorf("failed to set holiday policy for recurring event %d: %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/database"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+type RecurringExceptionRepository interface {
+	Save(ctx context.Context, exception *models.RecurringEventException) error
+	GetByEvent(ctx context.Context, recurringEventID int) ([]models.RecurringEventException, error)
+	GetAll(ctx context.Context) ([]models.RecurringEventException, error)
+	Delete(ctx context.Context, recurringEventID int, occurrenceDate string) error
+}
+
+type recurringExceptionRepository struct{}
+
+func NewRecurringExceptionRepository() RecurringExceptionRepository {
+	return &recurringExceptionRepository{}
+}
+
+// Save creates the exception or replaces the existing one for the same occurrence.
+func (r *recurringExceptionRepository) Save(ctx context.Context, exception *models.RecurringEventException) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO recurring_event_exceptions (
+			recurring_event_id, occurrence_date, is_cancelled,
+			override_date, override_time, override_location,
+			created_at, created_by
+		)
+		VALUES (
+			:recurring_event_id, :occurrence_date, :is_cancelled,
+			:override_date, :override_time, :override_location,
+			:created_at, :created_by
+		)
+		ON CONFLICT(recurring_event_id, occurrence_date) DO UPDATE SET
+			is_cancelled = :is_cancelled,
+			override_date = :override_date,
+			override_time = :override_time,
+			override_location = :override_location,
+			created_at = :created_at,
+			created_by = :created_by
+	`
+	_, err := database.DB.NamedExecContext(ctx, query, exception)
+	if err != nil {
+		return fmt.Errorf("failed to save exception for recurring event %d on %s: %w",
+			exception.RecurringEventID, exception.OccurrenceDate, err)
+	}
+
+	log.Printf("✅ Saved exception for recurring event %d on %s", exception.RecurringEventID, exception.OccurrenceDate)
+
+	return nil
+}
+
+func (r *recurringExceptionRepository) GetByEvent(ctx context.Context, recurringEventID int) ([]models.RecurringEventException, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var exceptions []models.RecurringEventException
+	query := `SELECT * FROM recurring_event_exceptions WHERE recurring_event_id = ? ORDER BY occurrence_date`
+
+	err := database.DB.SelectContext(ctx, &exceptions, query, recurringEventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exceptions for recurring event %d: %w", recurringEventID, err)
+	}
+
+	return exceptions, nil
+}
+
+func (r *recurringExceptionRepository) GetAll(ctx context.Context) ([]models.RecurringEventException, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var exceptions []models.RecurringEventException
+	query := `SELECT * FROM recurring_event_exceptions ORDER BY recurring_event_id, occurrence_date`
+
+	err := database.DB.SelectContext(ctx, &exceptions, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring event exceptions: %w", err)
+	}
+
+	return exceptions, nil
+}
+
+func (r *recurringExceptionRepository) Delete(ctx context.Context, recurringEventID int, occurrenceDate string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `DELETE FROM recurring_event_exceptions WHERE recurring_event_id = ? AND occurrence_date = ?`
+	_, err := database.DB.ExecContext(ctx, query, recurringEventID, occurrenceDate)
+	if err != nil {
+		return fmt.Errorf("failed to delete exception for recurring event %d on %s: %w", recurringEventID, occurrenceDate, err)
+	}
+
+	log.Printf("✅ Deleted exception for recurring event %d on %s", recurringEventID, occurrenceDate)
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/database"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+type ReminderLogRepository interface {
+	Exists(ctx context.Context, key string) (bool, error)
+	MarkSent(ctx context.Context, key string) (bool, error)
+	MarkSkipped(ctx context.Context, key string) (bool, error)
+}
+
+type reminderLogRepository struct{}
+
+func NewReminderLogRepository() ReminderLogRepository {
+	return &reminderLogRepository{}
+}
+
+func (r *reminderLogRepository) Exists(ctx context.Context, key string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var count int
+	query := `SELECT COUNT(*) FROM reminder_log WHERE reminder_key = ?`
+
+	err := database.DB.GetContext(ctx, &count, query, key)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return false, fmt.Errorf("database read timeout for reminder %s: %w", key, err)
+		}
+		return false, fmt.Errorf("failed to check reminder %s: %w", key, err)
+	}
+
+	return count > 0, nil
+}
+
+// MarkSent records the reminder key and reports whether it was recorded by
+// this call. A false result means the reminder was already handled earlier.
+func (r *reminderLogRepository) MarkSent(ctx context.Context, key string) (bool, error) {
+	return r.mark(ctx, key, models.ReminderStatusSent)
+}
+
+// MarkSkipped records a reminder that was deliberately not sent, so it is
+// neither sent nor reported again later.
+func (r *reminderLogRepository) MarkSkipped(ctx context.Context, key string) (bool, error) {
+	return r.mark(ctx, key, models.ReminderStatusSkipped)
+}
+
+func (r *reminderLogRepository) mark(ctx context.Context, key string, status string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `INSERT OR IGNORE INTO reminder_log (reminder_key, sent_at, status) VALUES (?, ?, ?)`
+
+	result, err := database.DB.ExecContext(ctx, query, key, time.Now(), status)
+	if err != nil {
+		if err == context.DeadlineExceeded {
+			return false, fmt.Errorf("database write timeout for reminder %s: %w", key, err)
+		}
+		return false, fmt.Errorf("failed to mark reminder %s: %w", key, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected for reminder %s: %w", key, err)
+	}
+
+	return affected == 1, nil
+}
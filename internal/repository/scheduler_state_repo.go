@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/database"
+)
+
+type SchedulerStateRepository interface {
+	GetLastTick(ctx context.Context) (*time.Time, error)
+	SetLastTick(ctx context.Context, t time.Time) error
+}
+
+type schedulerStateRepository struct{}
+
+func NewSchedulerStateRepository() SchedulerStateRepository {
+	return &schedulerStateRepository{}
+}
+
+// GetLastTick returns when the scheduler last checked for due reminders, or
+// nil if it never has.
+func (r *schedulerStateRepository) GetLastTick(ctx context.Context) (*time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var lastTick time.Time
+	query := `SELECT last_tick_at FROM scheduler_state WHERE id = 1`
+
+	err := database.DB.GetContext(ctx, &lastTick, query)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last scheduler tick: %w", err)
+	}
+
+	lastTick = clock.In(lastTick)
+	return &lastTick, nil
+}
+
+func (r *schedulerStateRepository) SetLastTick(ctx context.Context, t time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `INSERT INTO scheduler_state (id, last_tick_at) VALUES (1, ?)
+		ON CONFLICT (id) DO UPDATE SET last_tick_at = excluded.last_tick_at`
+
+	if _, err := database.DB.ExecContext(ctx, query, t.UTC()); err != nil {
+		return fmt.Errorf("failed to save last scheduler tick: %w", err)
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/database"
 	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
 )
@@ -139,8 +140,7 @@ func (r *userRepository) UpdateLastSeen(ctx context.Context, userID int64) error
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	location, _ := time.LoadLocation("Europe/Warsaw")
-	now := time.Now().In(location)
+	now := clock.Now()
 
 	query := `UPDATE users SET last_seen = ?, updated_at = ? WHERE user_id = ?`
 	_, err := database.DB.ExecContext(ctx, query, now, now, userID)
@@ -157,8 +157,7 @@ func (r *userRepository) SetActive(ctx context.Context, userID int64, isActive b
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	location, _ := time.LoadLocation("Europe/Warsaw")
-	now := time.Now().In(location)
+	now := clock.Now()
 
 	query := `UPDATE users SET is_active = ?, updated_at = ? WHERE user_id = ?`
 	_, err := database.DB.ExecContext(ctx, query, isActive, now, userID)
@@ -175,8 +174,7 @@ func (r *userRepository) SetBlocked(ctx context.Context, userID int64, isBlocked
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	location, _ := time.LoadLocation("Europe/Warsaw")
-	now := time.Now().In(location)
+	now := clock.Now()
 
 	query := `UPDATE users SET is_blocked = ?, updated_at = ? WHERE user_id = ?`
 	_, err := database.DB.ExecContext(ctx, query, isBlocked, now, userID)
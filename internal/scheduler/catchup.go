@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/middleware"
+)
+
+const (
+	// catchUpLookback is how far back the startup pass looks at most for
+	// reminders that fell due while the bot was down.
+	catchUpLookback = 48 * time.Hour
+
+	defaultGraceWindow = 30 * time.Minute
+)
+
+// graceWindowFromEnv reads REMINDER_GRACE_MINUTES, the longest delay after
+// which a missed reminder is still worth sending.
+func graceWindowFromEnv() time.Duration {
+	value := os.Getenv("REMINDER_GRACE_MINUTES")
+	if value == "" {
+		return defaultGraceWindow
+	}
+
+	minutes, err := strconv.Atoi(value)
+	if err != nil || minutes < 0 {
+		log.Printf("Warning: invalid REMINDER_GRACE_MINUTES %q, using %s", value, defaultGraceWindow)
+		return defaultGraceWindow
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
+
+// catchUp handles reminders that fell due while the bot was not running.
+// Reminders less than graceWindow late are sent now; older ones are marked
+// as skipped and reported to the admins instead of reaching users hours late.
+func (s *Scheduler) catchUp(ctx context.Context) {
+	now := time.Now().In(s.location)
+
+	lastTick, err := s.state.GetLastTick(ctx)
+	if err != nil {
+		log.Printf("Error getting last scheduler tick, skipping catch-up: %v", err)
+		return
+	}
+	// Without a last tick it is unknown what was sent before, e.g. on the
+	// first start, so nothing is caught up or reported.
+	if lastTick == nil {
+		log.Println("⏰ No previous scheduler tick recorded, skipping catch-up")
+		s.saveLastTick(ctx, now)
+		return
+	}
+
+	var missed []pendingReminder
+
+	from, to := catchUpWindow(now, *lastTick)
+	for _, reminder := range s.due(ctx, from, to) {
+		handled, err := s.reminderLog.Exists(ctx, reminder.key)
+		if err != nil {
+			log.Printf("Error checking reminder %s: %v", reminder.key, err)
+			continue
+		}
+		if !handled {
+			missed = append(missed, reminder)
+		}
+	}
+
+	send, late := splitLate(missed, now, s.graceWindow)
+
+	for _, reminder := range send {
+		log.Printf("⏰ Catching up reminder %s, %s late", reminder.key, now.Sub(reminder.remindAt).Round(time.Minute))
+		s.sendReminder(ctx, reminder)
+	}
+
+	var skipped []pendingReminder
+
+	for _, reminder := range late {
+		claimed, err := s.reminderLog.MarkSkipped(ctx, reminder.key)
+		if err != nil {
+			log.Printf("Error marking reminder %s as skipped: %v", reminder.key, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		log.Printf("⏭️ Skipped reminder %s: %s late, grace window is %s",
+			reminder.key, now.Sub(reminder.remindAt).Round(time.Minute), s.graceWindow)
+		skipped = append(skipped, reminder)
+	}
+
+	if len(skipped) > 0 {
+		s.reportSkipped(ctx, now, skipped)
+	}
+}
+
+// catchUpWindow returns the range (from, to] of reminder moments that the
+// startup pass at now looks at: those due after the last tick, at most
+// catchUpLookback ago.
+func catchUpWindow(now, lastTick time.Time) (from, to time.Time) {
+	from = now.Add(-catchUpLookback)
+	if lastTick.After(from) {
+		from = lastTick
+	}
+	return from, now
+}
+
+// splitLate splits missed reminders into those at most grace late at now,
+// which are still sent, and the later ones, which are skipped.
+func splitLate(missed []pendingReminder, now time.Time, grace time.Duration) (send, skip []pendingReminder) {
+	for _, reminder := range missed {
+		if now.Sub(reminder.remindAt) <= grace {
+			send = append(send, reminder)
+		} else {
+			skip = append(skip, reminder)
+		}
+	}
+	return send, skip
+}
+
+func (s *Scheduler) reportSkipped(ctx context.Context, now time.Time, skipped []pendingReminder) {
+	text := "⚠️ <b>Пропущені нагадування</b>\n\n" +
+		fmt.Sprintf("Бот був недоступний, тому ці нагадування не надіслано (запізнення понад %s):\n\n",
+			messages.FormatDuration(int(s.graceWindow.Minutes())))
+
+	for _, reminder := range skipped {
+		text += fmt.Sprintf("• <b>%s</b> — мало бути %s (запізнення %s)\n",
+			html.EscapeString(reminder.title),
+			reminder.remindAt.In(s.location).Format("02.01 о 15:04"),
+			messages.FormatDuration(int(now.Sub(reminder.remindAt).Minutes())))
+	}
+
+	for _, adminID := range middleware.AdminIDs() {
+		_, err := s.bot.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    adminID,
+			Text:      text,
+			ParseMode: models.ParseModeHTML,
+		})
+		if err != nil {
+			log.Printf("Error reporting skipped reminders to admin %d: %v", adminID, err)
+		}
+	}
+}
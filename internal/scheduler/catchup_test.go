@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCatchUp restarts the bot at different moments after the test
+// reminders fell due while it was down.
+func TestCatchUp(t *testing.T) {
+	const (
+		grace = 30 * time.Minute
+		both  = "event:1:60:1792512000, recurring:2:2026-10-20"
+	)
+
+	// The bot went down a minute before the reminders fell due.
+	wentDown := remindAt.Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		lastTick   time.Time
+		now        time.Time
+		send, skip string
+	}{
+		{"restart just after", wentDown, remindAt.Add(time.Minute), both, ""},
+		{"exactly grace late", wentDown, remindAt.Add(grace), both, ""},
+		{"just past grace", wentDown, remindAt.Add(grace + time.Second), "", both},
+		{"down for almost two days", wentDown, remindAt.Add(catchUpLookback - time.Minute), "", both},
+		// Older reminders are not looked at, not even to report them.
+		{"down for exactly the lookback", wentDown, remindAt.Add(catchUpLookback), "", ""},
+		{"down for three days", wentDown, remindAt.Add(72 * time.Hour), "", ""},
+		// The last tick has already handled them, whatever reminder_log says.
+		{"down just after the reminders were due", remindAt, remindAt.Add(time.Hour), "", ""},
+		{"last tick exactly before them", remindAt.Add(-time.Nanosecond), remindAt.Add(time.Hour), "", both},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := catchUpWindow(tt.now, tt.lastTick)
+			send, skip := splitLate(testRemindersDue(t, from, to), tt.now, grace)
+
+			if got := keys(send); got != tt.send {
+				t.Errorf("sent %q, want %q", got, tt.send)
+			}
+			if got := keys(skip); got != tt.skip {
+				t.Errorf("skipped %q, want %q", got, tt.skip)
+			}
+		})
+	}
+}
+
+func TestSplitLateWithoutGrace(t *testing.T) {
+	missed := []pendingReminder{
+		{key: "on time", remindAt: remindAt},
+		{key: "late", remindAt: remindAt.Add(-time.Second)},
+	}
+
+	send, skip := splitLate(missed, remindAt, 0)
+	if keys(send) != "on time" || keys(skip) != "late" {
+		t.Errorf("splitLate() sent %q and skipped %q", keys(send), keys(skip))
+	}
+}
+
+func TestGraceWindowFromEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", defaultGraceWindow},
+		{"0", 0},
+		{"90", 90 * time.Minute},
+		{"-5", defaultGraceWindow},
+		{"half an hour", defaultGraceWindow},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("REMINDER_GRACE_MINUTES", tt.value)
+		if got := graceWindowFromEnv(); got != tt.want {
+			t.Errorf("REMINDER_GRACE_MINUTES=%q gives %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+type eventReminder struct {
+	event    *internalModels.Event
+	reminder internalModels.EventReminder
+	remindAt time.Time
+}
+
+// key includes the event start, so moving the event to another date makes
+// its reminders due again.
+func (r eventReminder) key() string {
+	return fmt.Sprintf("event:%d:%d:%d", r.event.ID, r.reminder.OffsetMinutes, r.event.Date.Unix())
+}
+
+func (s *Scheduler) eventsDue(ctx context.Context, from, to time.Time) []pendingReminder {
+	reminders, err := s.dueEventReminders(ctx, from, to)
+	if err != nil {
+		log.Printf("Error getting event reminders: %v", err)
+		return nil
+	}
+
+	due := make([]pendingReminder, 0, len(reminders))
+	for _, reminder := range reminders {
+		pending := pendingReminder{
+			key:      reminder.key(),
+			title:    reminder.event.Title,
+			text:     formatEventReminder(reminder),
+			remindAt: reminder.remindAt,
+		}
+		if reminder.event.ImageFileID != nil {
+			pending.photo = *reminder.event.ImageFileID
+		}
+		due = append(due, pending)
+	}
+
+	return due
+}
+
+// dueEventReminders returns the reminders of upcoming published events whose
+// reminder moment falls within (from, to].
+func (s *Scheduler) dueEventReminders(ctx context.Context, from, to time.Time) ([]eventReminder, error) {
+	events, err := s.eventRepo.GetUpcoming(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders, err := s.eventReminder.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return eventRemindersBetween(events, reminders, from, to), nil
+}
+
+// eventRemindersBetween returns the reminders of events whose reminder moment
+// falls within (from, to].
+func eventRemindersBetween(events []internalModels.Event, reminders []internalModels.EventReminder, from, to time.Time) []eventReminder {
+	byEvent := make(map[int][]internalModels.EventReminder)
+	for _, reminder := range reminders {
+		byEvent[reminder.EventID] = append(byEvent[reminder.EventID], reminder)
+	}
+
+	var due []eventReminder
+
+	for i := range events {
+		start := events[i].Date
+
+		for _, reminder := range byEvent[events[i].ID] {
+			remindAt := start.Add(-reminder.Offset())
+			if remindAt.After(from) && !remindAt.After(to) {
+				due = append(due, eventReminder{
+					event:    &events[i],
+					reminder: reminder,
+					remindAt: remindAt,
+				})
+			}
+		}
+	}
+
+	return due
+}
+
+func formatEventReminder(reminder eventReminder) string {
+	return fmt.Sprintf("🔔 <b>Нагадування: подія %s</b>\n\n", reminderLead(reminder.reminder.OffsetMinutes)) +
+		fmt.Sprintf("<b>%s</b>\n", html.EscapeString(reminder.event.Title)) +
+		messages.FormatEventDetails(reminder.event)
+}
+
+func reminderLead(offsetMinutes int) string {
+	if offsetMinutes == 24*60 {
+		return "завтра"
+	}
+	return "через " + messages.FormatDuration(offsetMinutes)
+}
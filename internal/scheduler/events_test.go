@@ -0,0 +1,23 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+func TestEventReminderKeyChangesWithDate(t *testing.T) {
+	event := &internalModels.Event{ID: 7, Date: time.Date(2026, 10, 20, 16, 0, 0, 0, time.UTC)}
+	reminder := eventReminder{event: event, reminder: internalModels.EventReminder{OffsetMinutes: 60}}
+
+	before := reminder.key()
+	if before != "event:7:60:1792512000" {
+		t.Errorf("key() = %q", before)
+	}
+
+	event.Date = event.Date.AddDate(0, 0, 7)
+	if after := reminder.key(); after == before {
+		t.Errorf("key() = %q for both dates, moved events would never be reminded again", after)
+	}
+}
@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/keyboards"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+)
+
+// processPublishing publishes drafts whose scheduled publish time has come and
+// lets the admin who created each one know.
+func (s *Scheduler) processPublishing(ctx context.Context, now time.Time) {
+	events, err := s.eventRepo.GetDueForPublish(ctx, now)
+	if err != nil {
+		log.Printf("Error getting events due for publishing: %v", err)
+		return
+	}
+
+	for _, event := range events {
+		if err := s.eventRepo.SetPublished(ctx, event.ID, true); err != nil {
+			log.Printf("Error publishing event %d: %v", event.ID, err)
+			continue
+		}
+
+		log.Printf("📢 Event %d published on schedule", event.ID)
+		event.IsPublished = true
+
+		text := "📢 <b>Подію опубліковано за розкладом</b>\n\n" +
+			fmt.Sprintf("<b>%s</b>\n", html.EscapeString(event.Title)) +
+			fmt.Sprintf("📅 %s\n", messages.FormatEventDate(&event)) +
+			fmt.Sprintf("ID: %d", event.ID)
+
+		_, err := s.bot.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      event.CreatedBy,
+			Text:        text,
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: keyboards.EventCreatedKeyboard(&event),
+		})
+		if err != nil {
+			log.Printf("Error notifying admin %d about published event %d: %v", event.CreatedBy, event.ID, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"time"
+
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+type recurringReminder struct {
+	occurrence internalModels.Occurrence
+	remindAt   time.Time
+}
+
+// key uses the scheduled date, so moving an occurrence never re-sends it.
+func (r recurringReminder) key() string {
+	return fmt.Sprintf("recurring:%d:%s", r.occurrence.Event.ID, r.occurrence.ScheduledDate)
+}
+
+// recurringDue returns the reminders of active series whose reminder moment
+// falls within (from, to].
+func (s *Scheduler) recurringDue(ctx context.Context, from, to time.Time) []pendingReminder {
+	events, err := s.recurringRepo.GetActive(ctx)
+	if err != nil {
+		log.Printf("Error getting active recurring events: %v", err)
+		return nil
+	}
+
+	var due []pendingReminder
+
+	for i := range events {
+		exceptions, err := s.exceptionRepo.GetByEvent(ctx, events[i].ID)
+		if err != nil {
+			log.Printf("Error getting exceptions for recurring event %d: %v", events[i].ID, err)
+			continue
+		}
+
+		reminders, err := recurringReminders(&events[i], exceptions, from, to, s.location)
+		if err != nil {
+			log.Printf("Skipping recurring event %d: %v", events[i].ID, err)
+			continue
+		}
+
+		for _, reminder := range reminders {
+			due = append(due, pendingReminder{
+				key:      reminder.key(),
+				title:    events[i].Title,
+				text:     formatRecurringReminder(reminder),
+				remindAt: reminder.remindAt,
+			})
+		}
+	}
+
+	return due
+}
+
+// recurringReminders returns the reminders of event whose reminder moment
+// falls within (from, to]. Cancelled occurrences get no reminder.
+func recurringReminders(event *internalModels.RecurringEvent, exceptions []internalModels.RecurringEventException, from, to time.Time, location *time.Location) ([]recurringReminder, error) {
+	reminderHour, reminderMinute, err := internalModels.ParseClockTime(event.ReminderTime)
+	if err != nil {
+		return nil, err
+	}
+
+	from = from.In(location)
+	to = to.In(location)
+
+	// The reminder is sent ReminderDayOffset days relative to the occurrence,
+	// so shift the search range the opposite way, with a day of slack.
+	occurrences, err := event.ResolvedOccurrences(
+		from.AddDate(0, 0, -event.ReminderDayOffset-1),
+		to.AddDate(0, 0, -event.ReminderDayOffset+1),
+		location,
+		exceptions,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var reminders []recurringReminder
+
+	for _, occurrence := range occurrences {
+		if occurrence.IsCancelled {
+			continue
+		}
+
+		day := occurrence.Start.AddDate(0, 0, event.ReminderDayOffset)
+		remindAt := time.Date(day.Year(), day.Month(), day.Day(), reminderHour, reminderMinute, 0, 0, location)
+
+		if remindAt.After(from) && !remindAt.After(to) {
+			reminders = append(reminders, recurringReminder{
+				occurrence: occurrence,
+				remindAt:   remindAt,
+			})
+		}
+	}
+
+	return reminders, nil
+}
+
+func formatRecurringReminder(reminder recurringReminder) string {
+	occurrence := reminder.occurrence
+	event := occurrence.Event
+
+	text := "🔔 <b>Нагадування</b>\n\n" +
+		fmt.Sprintf("<b>%s</b>\n", html.EscapeString(event.Title)) +
+		fmt.Sprintf("📅 %s, %s\n",
+			internalModels.WeekdayName(int(occurrence.Start.Weekday())),
+			occurrence.Start.Format("02.01.2006 о 15:04"))
+
+	if occurrence.IsChanged {
+		text += "⚠️ <i>Зверніть увагу: цього разу змінено час або місце</i>\n"
+	}
+	if event.Description != "" {
+		text += fmt.Sprintf("📝 %s\n", html.EscapeString(event.Description))
+	}
+	if occurrence.Location != "" {
+		text += fmt.Sprintf("📍 %s\n", html.EscapeString(occurrence.Location))
+	}
+	if event.RegistrationURL != "" {
+		text += fmt.Sprintf("🔗 <a href=\"%s\">Реєстрація</a>\n", html.EscapeString(event.RegistrationURL))
+	}
+
+	return text
+}
@@ -0,0 +1,193 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/messages"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/middleware"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+// retentionPolicy says what happens to events some months after they end.
+// With months 0 old events are kept as they are.
+type retentionPolicy struct {
+	months int
+	// delete removes old events after sending them to the admins as a file;
+	// otherwise they are only archived, i.e. hidden from users.
+	delete bool
+}
+
+// retentionPolicyFromEnv reads EVENT_RETENTION_MONTHS and EVENT_RETENTION_MODE
+// ("archive" or "delete").
+func retentionPolicyFromEnv() retentionPolicy {
+	var policy retentionPolicy
+
+	if value := os.Getenv("EVENT_RETENTION_MONTHS"); value != "" {
+		months, err := strconv.Atoi(value)
+		if err != nil || months < 0 {
+			log.Printf("Warning: invalid EVENT_RETENTION_MONTHS %q, keeping all events", value)
+			return retentionPolicy{}
+		}
+		policy.months = months
+	}
+
+	switch mode := strings.ToLower(os.Getenv("EVENT_RETENTION_MODE")); mode {
+	case "", "archive":
+	case "delete":
+		policy.delete = true
+	default:
+		log.Printf("Warning: invalid EVENT_RETENTION_MODE %q, archiving old events", mode)
+	}
+
+	return policy
+}
+
+// processRetention applies the retention policy once a day.
+func (s *Scheduler) processRetention(ctx context.Context, now time.Time) {
+	if s.retention.months == 0 {
+		return
+	}
+
+	today := now.Format("2006-01-02")
+	if s.lastRetention == today {
+		return
+	}
+	s.lastRetention = today
+
+	cutoff := clock.StartOfDay(now).AddDate(0, -s.retention.months, 0)
+
+	if !s.retention.delete {
+		archived, err := s.eventRepo.ArchiveEndedBefore(ctx, cutoff)
+		if err != nil {
+			log.Printf("Error archiving old events: %v", err)
+			return
+		}
+		if archived > 0 {
+			log.Printf("🗄 Archived %d event(s) ended before %s", archived, cutoff.Format("02.01.2006"))
+		}
+		return
+	}
+
+	events, err := s.eventRepo.GetEndedBefore(ctx, cutoff)
+	if err != nil {
+		log.Printf("Error getting old events: %v", err)
+		return
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	// The events are deleted only once at least one admin has the file.
+	if !s.sendDeletedEvents(ctx, events, cutoff) {
+		log.Printf("Old events were not deleted: no admin received the export")
+		return
+	}
+
+	ids := make([]int, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+
+	if err := s.eventRepo.DeleteMany(ctx, ids); err != nil {
+		log.Printf("Error deleting old events: %v", err)
+		return
+	}
+
+	log.Printf("🗑 Deleted %d event(s) ended before %s", len(events), cutoff.Format("02.01.2006"))
+}
+
+// sendDeletedEvents sends the events about to be deleted to the admins as a
+// CSV file that the event import accepts. It reports whether any admin
+// received it.
+func (s *Scheduler) sendDeletedEvents(ctx context.Context, events []internalModels.Event, cutoff time.Time) bool {
+	data := eventsCSV(events)
+
+	caption := "🗄 <b>Видалення старих подій</b>\n\n" +
+		fmt.Sprintf("За політикою зберігання видаляються %s, що завершилися до %s.\n\n",
+			messages.Plural(len(events), "подія", "події", "подій"), cutoff.Format("02.01.2006")) +
+		"Файл можна знову завантажити через 📥 Імпорт з файлу."
+
+	sent := false
+
+	for _, adminID := range middleware.AdminIDs() {
+		_, err := s.bot.SendDocument(ctx, &bot.SendDocumentParams{
+			ChatID: adminID,
+			Document: &models.InputFileUpload{
+				Filename: fmt.Sprintf("events_until_%s.csv", cutoff.Format("2006-01-02")),
+				Data:     bytes.NewReader(data),
+			},
+			Caption:   caption,
+			ParseMode: models.ParseModeHTML,
+		})
+		if err != nil {
+			log.Printf("Error sending deleted events to admin %d: %v", adminID, err)
+			continue
+		}
+		sent = true
+	}
+
+	return sent
+}
+
+// eventsCSV renders the events in the column layout of the event import.
+// The UTF-8 BOM makes Excel read the Cyrillic text correctly.
+func eventsCSV(events []internalModels.Event) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"Назва", "Дата", "Час", "Кінець", "Час завершення", "Опис", "Місце", "Категорія", "Реєстрація", "Фотозвіт"})
+
+	optional := func(value *string) string {
+		if value == nil {
+			return ""
+		}
+		return *value
+	}
+
+	for _, event := range events {
+		start := clock.In(event.Date)
+
+		var startTime, end, endTime string
+		if !event.AllDay {
+			startTime = start.Format("15:04")
+		}
+		if event.EndDate != nil {
+			last := clock.In(*event.EndDate)
+			if event.IsMultiDay() {
+				end = last.Format("02.01.2006")
+			}
+			if !event.AllDay {
+				endTime = last.Format("15:04")
+			}
+		}
+
+		w.Write([]string{
+			event.Title,
+			start.Format("02.01.2006"),
+			startTime,
+			end,
+			endTime,
+			event.Description,
+			optional(event.Location),
+			optional(event.Category),
+			optional(event.RegistrationURL),
+			optional(event.ReportURL),
+		})
+	}
+
+	w.Flush()
+
+	return buf.Bytes()
+}
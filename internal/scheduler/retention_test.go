@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+// exportedEventsCSV is what the event import reads back, see
+// TestParseImportCSVExportLayout in the handlers package.
+const exportedEventsCSV = "\ufeff" +
+	"Назва,Дата,Час,Кінець,Час завершення,Опис,Місце,Категорія,Реєстрація,Фотозвіт\n" +
+	"Молодіжна зустріч,25.10.2026,18:00,,20:30,\"Хвала, молитва\",Зал,Молодь,https://example.com/r,https://example.com/photos\n" +
+	"Табір,15.07.2026,,17.07.2026,,,,,,\n" +
+	"Конференція,20.11.2026,18:00,22.11.2026,14:00,,,,,\n" +
+	"День подяки,29.03.2026,,,,,,,,\n"
+
+func exportedEvents() []internalModels.Event {
+	ptr := func(value string) *string { return &value }
+	at := func(t time.Time) *time.Time { return &t }
+
+	// Times come from the repository in UTC.
+	return []internalModels.Event{
+		{
+			Title:           "Молодіжна зустріч",
+			Date:            clock.Date(2026, time.October, 25, 18, 0).UTC(),
+			EndDate:         at(clock.Date(2026, time.October, 25, 20, 30).UTC()),
+			Description:     "Хвала, молитва",
+			Location:        ptr("Зал"),
+			Category:        ptr("Молодь"),
+			RegistrationURL: ptr("https://example.com/r"),
+			ReportURL:       ptr("https://example.com/photos"),
+		},
+		{
+			Title:   "Табір",
+			Date:    clock.Date(2026, time.July, 15, 0, 0).UTC(),
+			EndDate: at(clock.Date(2026, time.July, 17, 0, 0).UTC()),
+			AllDay:  true,
+		},
+		{
+			Title:   "Конференція",
+			Date:    clock.Date(2026, time.November, 20, 18, 0).UTC(),
+			EndDate: at(clock.Date(2026, time.November, 22, 14, 0).UTC()),
+		},
+		{
+			Title:  "День подяки",
+			Date:   clock.Date(2026, time.March, 29, 0, 0).UTC(),
+			AllDay: true,
+		},
+	}
+}
+
+func TestEventsCSV(t *testing.T) {
+	if got := string(eventsCSV(exportedEvents())); got != exportedEventsCSV {
+		t.Errorf("eventsCSV() =\n%q\nwant\n%q", got, exportedEventsCSV)
+	}
+}
@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/broadcast"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/repository"
+)
+
+const (
+	tickInterval = time.Minute
+
+	// sendWindow is how late a due reminder may still go out. It covers a few
+	// missed ticks, e.g. while a previous broadcast was still running.
+	sendWindow = 5 * time.Minute
+)
+
+// pendingReminder is a reminder computed from the schedule, ready to be sent.
+type pendingReminder struct {
+	key      string
+	title    string
+	text     string
+	remindAt time.Time
+	// photo is the file_id of the event poster sent with the reminder.
+	photo string
+}
+
+type Scheduler struct {
+	bot           *bot.Bot
+	recurringRepo repository.RecurringEventRepository
+	exceptionRepo repository.RecurringExceptionRepository
+	eventRepo     repository.EventRepository
+	eventReminder repository.EventReminderRepository
+	reminderLog   repository.ReminderLogRepository
+	state         repository.SchedulerStateRepository
+	location      *time.Location
+	graceWindow   time.Duration
+	retention     retentionPolicy
+	// lastRetention is the day ("2006-01-02") the retention policy was
+	// last applied.
+	lastRetention string
+}
+
+func New(b *bot.Bot) *Scheduler {
+	return &Scheduler{
+		bot:           b,
+		recurringRepo: repository.NewRecurringEventRepository(),
+		exceptionRepo: repository.NewRecurringExceptionRepository(),
+		eventRepo:     repository.NewEventRepository(),
+		eventReminder: repository.NewEventReminderRepository(),
+		reminderLog:   repository.NewReminderLogRepository(),
+		state:         repository.NewSchedulerStateRepository(),
+		location:      clock.Location(),
+		graceWindow:   graceWindowFromEnv(),
+		retention:     retentionPolicyFromEnv(),
+	}
+}
+
+// Start runs the scheduler loop until ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	log.Println("⏰ Reminder scheduler started")
+
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	s.catchUp(ctx)
+	s.tick(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("⏰ Reminder scheduler stopped")
+			return
+		case <-ticker.C:
+			s.tick(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	now := time.Now().In(s.location)
+
+	s.processPublishing(ctx, now)
+	s.processRetention(ctx, now)
+
+	from, to := tickWindow(now)
+	for _, reminder := range s.due(ctx, from, to) {
+		s.sendReminder(ctx, reminder)
+	}
+
+	s.saveLastTick(ctx, now)
+}
+
+// saveLastTick records that reminders due up to now were handled, so the
+// catch-up pass after a restart starts from there.
+func (s *Scheduler) saveLastTick(ctx context.Context, now time.Time) {
+	if err := s.state.SetLastTick(ctx, now); err != nil {
+		log.Printf("Error saving last scheduler tick: %v", err)
+	}
+}
+
+// tickWindow returns the range (from, to] of reminder moments that a tick at
+// now sends: those due in the last sendWindow.
+func tickWindow(now time.Time) (from, to time.Time) {
+	return now.Add(-sendWindow), now
+}
+
+// due returns all reminders whose reminder moment falls within (from, to].
+func (s *Scheduler) due(ctx context.Context, from, to time.Time) []pendingReminder {
+	return append(s.recurringDue(ctx, from, to), s.eventsDue(ctx, from, to)...)
+}
+
+// sendReminder broadcasts the reminder to all subscribers unless its key is
+// already in reminder_log. The key is claimed before sending, so a crash or
+// restart mid-broadcast can never deliver the same reminder twice.
+func (s *Scheduler) sendReminder(ctx context.Context, reminder pendingReminder) {
+	key := reminder.key
+
+	sent, err := s.reminderLog.Exists(ctx, key)
+	if err != nil {
+		log.Printf("Error checking reminder %s: %v", key, err)
+		return
+	}
+	if sent {
+		return
+	}
+
+	claimed, err := s.reminderLog.MarkSent(ctx, key)
+	if err != nil {
+		log.Printf("Error marking reminder %s: %v", key, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	message := broadcast.Message{Text: reminder.text, PhotoFileID: reminder.photo}
+
+	result, err := broadcast.ToActive(ctx, s.bot, message.Send)
+	if err != nil {
+		log.Printf("Error sending reminder %s: %v", key, err)
+		return
+	}
+
+	log.Printf("🔔 Reminder %s sent: %d/%d delivered, %d blocked, %d failed",
+		key, result.Sent, result.Total, result.Blocked, result.Failed)
+}
@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/malyyboh/slowo-wiary-warszawa-bot/internal/clock"
+	internalModels "github.com/malyyboh/slowo-wiary-warszawa-bot/internal/models"
+)
+
+// Both test reminders fall due on Tuesday 20.10.2026 at 17:00: one an hour
+// before an 18:00 event, the other on the day of a weekly 19:00 service.
+var remindAt = clock.Date(2026, time.October, 20, 17, 0)
+
+// testRemindersDue returns the test reminders due within (from, to].
+func testRemindersDue(t *testing.T, from, to time.Time) []pendingReminder {
+	t.Helper()
+
+	events := []internalModels.Event{
+		{ID: 1, Title: "Вечір хвали", Date: clock.Date(2026, time.October, 20, 18, 0).UTC()},
+	}
+	eventReminders := []internalModels.EventReminder{
+		{EventID: 1, OffsetMinutes: 60},
+	}
+
+	var due []pendingReminder
+	for _, reminder := range eventRemindersBetween(events, eventReminders, from, to) {
+		due = append(due, pendingReminder{key: reminder.key(), remindAt: reminder.remindAt})
+	}
+
+	series := &internalModels.RecurringEvent{
+		ID:            2,
+		Title:         "Домашня група",
+		DayOfWeek:     int(time.Tuesday),
+		EventTime:     "19:00",
+		StartDate:     "2026-01-06",
+		ReminderTime:  "17:00",
+		HolidayPolicy: internalModels.HolidayPolicyRun,
+	}
+	recurring, err := recurringReminders(series, nil, from, to, clock.Location())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, reminder := range recurring {
+		due = append(due, pendingReminder{key: reminder.key(), remindAt: reminder.remindAt})
+	}
+
+	return due
+}
+
+func keys(reminders []pendingReminder) string {
+	var keys []string
+	for _, reminder := range reminders {
+		keys = append(keys, reminder.key)
+	}
+	return strings.Join(keys, ", ")
+}
+
+func TestTickWindow(t *testing.T) {
+	const both = "event:1:60:1792512000, recurring:2:2026-10-20"
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want string
+	}{
+		{"a second early", remindAt.Add(-time.Second), ""},
+		{"on time", remindAt, both},
+		{"a few ticks late", remindAt.Add(sendWindow - time.Second), both},
+		// The tick a minute earlier has already sent them.
+		{"exactly sendWindow late", remindAt.Add(sendWindow), ""},
+		{"a day later", remindAt.Add(24 * time.Hour), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := tickWindow(tt.now)
+			if got := keys(testRemindersDue(t, from, to)); got != tt.want {
+				t.Errorf("due at %s: %q, want %q", tt.now.Format("02.01 15:04:05"), got, tt.want)
+			}
+		})
+	}
+}